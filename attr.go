@@ -0,0 +1,93 @@
+package slogx
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// OpenTelemetry resource semantic convention keys used by ResourceAttrs.
+const (
+	KeyServiceName    = "service.name"
+	KeyServiceVersion = "service.version"
+	KeyDeploymentEnv  = "deployment.environment"
+)
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Strings returns an Attr for a slice of strings.
+// Value is JSON array (see JSON).
+func Strings(key string, values []string) slog.Attr {
+	return JSON(key, values)
+}
+
+// Ints returns an Attr for a slice of integers.
+// Value is JSON array (see JSON).
+func Ints[T Integer](key string, values []T) slog.Attr {
+	if values == nil {
+		return JSON(key, nil)
+	}
+	var zero T
+	signed := zero-1 < zero
+	buf := []byte{'['}
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if signed {
+			buf = strconv.AppendInt(buf, int64(v), 10)
+		} else {
+			buf = strconv.AppendUint(buf, uint64(v), 10)
+		}
+	}
+	buf = append(buf, ']')
+	return slog.Any(key, json.RawMessage(buf))
+}
+
+// Map returns an Attr for a map.
+// Value is a group with map keys sorted.
+func Map[T any](key string, m map[string]T) slog.Attr {
+	keys := slices.Sorted(maps.Keys(m))
+	attrs := make([]slog.Attr, len(keys))
+	for i, k := range keys {
+		attrs[i] = slog.Any(k, m[k])
+	}
+	return slog.Attr{Key: key, Value: slog.GroupValue(attrs...)}
+}
+
+// JSON returns an Attr for v marshaled to JSON.
+// Value is json.RawMessage, so slog.JSONHandler outputs it as is
+// and slog.TextHandler outputs it as a string.
+// If marshaling fails then value is a string "!ERROR:" followed by an error.
+func JSON(key string, v any) slog.Attr {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return slog.String(key, "!ERROR:"+err.Error())
+	}
+	return slog.Any(key, json.RawMessage(buf))
+}
+
+// ResourceAttrs returns attrs describing a service using OpenTelemetry
+// resource semantic convention keys, followed by extra attrs.
+// Empty service, version or env are omitted.
+//
+// To add them to each record use handler.WithAttrs(slogx.ResourceAttrs(...)).
+func ResourceAttrs(service, version, env string, extra ...slog.Attr) []slog.Attr {
+	attrs := []slog.Attr{}
+	if service != "" {
+		attrs = append(attrs, slog.String(KeyServiceName, service))
+	}
+	if version != "" {
+		attrs = append(attrs, slog.String(KeyServiceVersion, version))
+	}
+	if env != "" {
+		attrs = append(attrs, slog.String(KeyDeploymentEnv, env))
+	}
+	return append(attrs, extra...)
+}
@@ -0,0 +1,62 @@
+package slogx_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestAttrConstructors(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	type myInt uint8
+	attrs := []slog.Attr{
+		slogx.Strings("strs", []string{"a", "b c"}),
+		slogx.Strings("nil", nil),
+		slogx.Ints("ints", []int{1, -2}),
+		slogx.Ints("bytes", []myInt{3, 255}),
+		slogx.Ints[int]("nilints", nil),
+		slogx.Map("map", map[string]int{"b": 2, "a": 1}),
+		slogx.Map("empty", map[string]int{}),
+		slogx.JSON("json", struct{ A int }{A: 1}),
+		slogx.JSON("bad", func() {}),
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("msg", attrsToArgs(attrs)...)
+	t.Match(buf.String(), `"msg":"msg","strs":\["a","b c"\],"nil":null,"ints":\[1,-2\],"bytes":\[3,255\],"nilints":null,"map":{"a":1,"b":2},"json":{"A":1},"bad":"!ERROR:json: unsupported type: func\(\)"}`)
+
+	buf.Reset()
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("msg", attrsToArgs(attrs)...)
+	t.Match(buf.String(), `msg=msg strs="\[\\"a\\",\\"b c\\"\]" nil="null" ints="\[1,-2\]" bytes="\[3,255\]" nilints="null" map.a=1 map.b=2 json="{\\"A\\":1}" bad="!ERROR:json: unsupported type: func\(\)"`)
+}
+
+func attrsToArgs(attrs []slog.Attr) []any {
+	args := make([]any, len(attrs))
+	for i := range attrs {
+		args[i] = attrs[i]
+	}
+	return args
+}
+
+func TestResourceAttrs(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	t.DeepEqual(slogx.ResourceAttrs("", "", ""), []slog.Attr{})
+	t.DeepEqual(slogx.ResourceAttrs("api", "", "prod", slog.String("host.name", "srv1")), []slog.Attr{
+		slog.String(slogx.KeyServiceName, "api"),
+		slog.String(slogx.KeyDeploymentEnv, "prod"),
+		slog.String("host.name", "srv1"),
+	})
+	t.DeepEqual(slogx.ResourceAttrs("api", "v1.2.3", "prod"), []slog.Attr{
+		slog.String(slogx.KeyServiceName, "api"),
+		slog.String(slogx.KeyServiceVersion, "v1.2.3"),
+		slog.String(slogx.KeyDeploymentEnv, "prod"),
+	})
+}
@@ -0,0 +1,65 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// CanonicalLine accumulates attrs during request processing to be logged
+// at request end as a single wide record ("canonical log line").
+// It is safe for concurrent use.
+//
+// All methods of nil *CanonicalLine do nothing, so code which adds attrs
+// does not need to check is CanonicalLine present in a context.
+type CanonicalLine struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// NewContextWithCanonical returns a new Context that carries new empty CanonicalLine.
+func NewContextWithCanonical(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyCanonical, &CanonicalLine{})
+}
+
+// Canonical returns a CanonicalLine value stored in ctx if exists or nil.
+func Canonical(ctx context.Context) *CanonicalLine {
+	c, _ := ctx.Value(contextKeyCanonical).(*CanonicalLine)
+	return c
+}
+
+// Set adds attr with given key and value or replaces value of existing attr.
+func (c *CanonicalLine) Set(key string, value any) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := slices.IndexFunc(c.attrs, func(a slog.Attr) bool { return a.Key == key })
+	if i < 0 {
+		c.attrs = append(c.attrs, slog.Any(key, value))
+	} else {
+		c.attrs[i].Value = slog.AnyValue(value)
+	}
+}
+
+// Attrs returns a copy of accumulated attrs in order they were first set.
+func (c *CanonicalLine) Attrs() []slog.Attr {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.attrs)
+}
+
+// Log emits a record with accumulated attrs using handler of default logger.
+func (c *CanonicalLine) Log(ctx context.Context, level slog.Level, msg string) {
+	if c == nil {
+		return
+	}
+	LogAttrsSkip(ctx, 1, slog.Default().Handler(), level, msg, c.Attrs()...)
+}
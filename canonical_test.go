@@ -0,0 +1,44 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestCanonical(tt *testing.T) {
+	t := check.T(tt)
+
+	ctx := context.Background()
+	t.Nil(slogx.Canonical(ctx))
+	t.NotPanic(func() {
+		slogx.Canonical(ctx).Set("key", "value")
+		slogx.Canonical(ctx).Log(ctx, slog.LevelInfo, "request")
+	})
+	t.Nil(slogx.Canonical(ctx).Attrs())
+
+	var buf bytes.Buffer
+	ctx = slogx.SetDefaultCtxHandler(context.Background(), slog.NewTextHandler(&buf, &slog.HandlerOptions{AddSource: true}))
+	ctx = slogx.NewContextWithCanonical(ctx)
+	slogx.Canonical(ctx).Set("db_calls", 1)
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slogx.Canonical(ctx).Set("cache", true)
+		}()
+	}
+	wg.Wait()
+	slogx.Canonical(ctx).Set("db_calls", 12)
+	t.DeepEqual(slogx.Canonical(ctx).Attrs(), []slog.Attr{slog.Int("db_calls", 12), slog.Bool("cache", true)})
+
+	slogx.Canonical(ctx).Log(ctx, slog.LevelInfo, "request")
+	t.Match(buf.String(), `level=INFO source=\S*/slogx/canonical_test.go:\d+ msg=request db_calls=12 cache=true\n$`)
+}
@@ -10,6 +10,9 @@ type contextKey int
 const (
 	contextKeyLog contextKey = iota
 	contextKeyHandler
+	contextKeyCanonical
+	contextKeyLevel
+	contextKeyBadKey
 )
 
 // NewContextWithHandler returns a new Context that carries value handler.
@@ -33,3 +36,18 @@ func LoggerFromContext(ctx context.Context) *slog.Logger {
 	log, _ := ctx.Value(contextKeyLog).(*slog.Logger)
 	return log
 }
+
+// ContextWithLevel returns a new Context that carries level.
+// For records logged with this ctx CtxHandler uses this level instead of
+// level of the handler (both when it is lower and higher), e.g. to enable
+// DEBUG records for a single request or disable INFO records for a noisy one.
+func ContextWithLevel(ctx context.Context, level slog.Level) context.Context {
+	return context.WithValue(ctx, contextKeyLevel, level)
+}
+
+// LevelFromContext returns a level stored in ctx by ContextWithLevel
+// and true if exists or false.
+func LevelFromContext(ctx context.Context) (slog.Level, bool) {
+	level, ok := ctx.Value(contextKeyLevel).(slog.Level)
+	return level, ok
+}
@@ -37,3 +37,15 @@ func TestContextLogger(tt *testing.T) {
 	ctx = slogx.NewContextWithLogger(context.Background(), log)
 	t.Equal(slogx.LoggerFromContext(ctx), log)
 }
+
+func TestContextLevel(tt *testing.T) {
+	t := check.T(tt)
+
+	_, ok := slogx.LevelFromContext(context.Background())
+	t.False(ok)
+
+	ctx := slogx.ContextWithLevel(context.Background(), slog.LevelDebug)
+	level, ok := slogx.LevelFromContext(ctx)
+	t.True(ok)
+	t.Equal(level, slog.LevelDebug)
+}
@@ -3,6 +3,8 @@ package slogx
 import (
 	"context"
 	"log/slog"
+	"slices"
+	"time"
 )
 
 const (
@@ -10,6 +12,9 @@ const (
 	badCtx = "!BADCTX"
 )
 
+// KeyCtxDeadline is a key of attr added by CtxHandler with DeadlineCtxHandler option.
+const KeyCtxDeadline = "ctx_deadline_ms"
+
 // CtxHandler provides a way to use slog.Handler stored in a context instead of slog.Logger.
 // This makes possible to store extra slog.Attr inside a context and make it magically work
 // without needs to get slog.Logger out of context each time you need to log something.
@@ -81,10 +86,25 @@ const (
 //
 // By default CtxHandler will add attr with key "!BADCTX" and value ctx if ctx does not contain
 // slog handler, but this can be disabled using LaxCtxHandler option.
+//
+// Attrs with key "!BADKEY" (result of malformed key/value args) are logged as is,
+// but this can be changed using BadKeyCtxHandler option.
+//
+// Remaining time until ctx deadline may be added to WARN and ERROR records
+// using DeadlineCtxHandler option.
+//
+// ID of goroutine which logs a record may be added using
+// GoroutineIDCtxHandler option.
+//
+// Level of handlers may be overridden for records logged with some ctx
+// (e.g. to enable DEBUG records for a single request) using ContextWithLevel.
 type CtxHandler struct {
-	fallback   slog.Handler
-	ops        []handlerOp
-	omitBadCtx bool
+	fallback       slog.Handler
+	ops            []handlerOp
+	omitBadCtx     bool
+	badKey         func(slog.Attr) slog.Attr
+	addDeadline    bool
+	addGoroutineID bool
 }
 
 type handlerOp struct {
@@ -105,8 +125,13 @@ func newCtxHandler(fallback slog.Handler, opts ...ctxHandlerOption) *CtxHandler
 }
 
 // Enabled implements slog.Handler interface.
-// It uses handler returned by HandlerFromContext or fallback handler.
+// It uses level stored in ctx by ContextWithLevel if exists (ignoring
+// level of handlers), otherwise handler returned by HandlerFromContext
+// or fallback handler.
 func (h *CtxHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	if level, ok := LevelFromContext(ctx); ok {
+		return l >= level
+	}
 	handler := HandlerFromContext(ctx)
 	if handler == nil {
 		handler = h.fallback
@@ -117,7 +142,22 @@ func (h *CtxHandler) Enabled(ctx context.Context, l slog.Level) bool {
 // Handle implements slog.Handler interface.
 // It uses handler returned by HandlerFromContext or fallback handler.
 // Adds !BADCTX attr if HandlerFromContext returns nil. Use LaxCtxHandler to disable this behaviour.
+// Drops record with level below level stored in ctx by ContextWithLevel.
 func (h *CtxHandler) Handle(ctx context.Context, r slog.Record) error {
+	if level, ok := LevelFromContext(ctx); ok && r.Level < level {
+		return nil
+	}
+	if h.badKey != nil {
+		r = h.replaceBadKey(r)
+	}
+	if deadline, ok := ctx.Deadline(); ok && h.addDeadline && r.Level >= slog.LevelWarn {
+		r = r.Clone()
+		r.AddAttrs(slog.Int64(KeyCtxDeadline, time.Until(deadline).Milliseconds()))
+	}
+	if h.addGoroutineID {
+		r = r.Clone()
+		r.AddAttrs(GoroutineID())
+	}
 	handler := HandlerFromContext(ctx)
 	if handler == nil {
 		handler = h.fallback
@@ -136,7 +176,11 @@ func (h *CtxHandler) Handle(ctx context.Context, r slog.Record) error {
 }
 
 // WithAttrs implements slog.Handler interface.
+// It applies BadKeyCtxHandler option to attrs.
 func (h *CtxHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if h.badKey != nil {
+		attrs = replaceBadKeyAttrs(h.badKey, slices.Clone(attrs))
+	}
 	if len(attrs) == 0 {
 		return h
 	}
@@ -153,17 +197,35 @@ func (h *CtxHandler) WithGroup(name string) slog.Handler {
 	return ctxHandler
 }
 
+// Unwrap returns fallback handler.
+func (h *CtxHandler) Unwrap() slog.Handler {
+	return h.fallback
+}
+
 // SetDefaultCtxHandler sets a CtxHandler as a default logger's handler
 // and returns context with this handler inside.
+// Returned context also carries BadKeyCtxHandler option (if given)
+// for ContextWithAttrs.
 func SetDefaultCtxHandler(ctx context.Context, fallback slog.Handler, opts ...ctxHandlerOption) context.Context {
-	slog.SetDefault(slog.New(newCtxHandler(fallback, opts...)))
+	ctxHandler := newCtxHandler(fallback, opts...)
+	slog.SetDefault(slog.New(ctxHandler))
+	if ctxHandler.badKey != nil {
+		ctx = context.WithValue(ctx, contextKeyBadKey, ctxHandler.badKey)
+	}
 	return NewContextWithHandler(ctx, fallback)
 }
 
 // ContextWithAttrs applies attrs to a handler stored in ctx.
+// Attrs with !BADKEY key are handled by BadKeyCtxHandler option only if
+// ctx was derived from context returned by SetDefaultCtxHandler called
+// with this option.
 func ContextWithAttrs(ctx context.Context, attrs ...any) context.Context {
 	handler := HandlerFromContext(ctx)
-	return NewContextWithHandler(ctx, handler.WithAttrs(argsToAttrSlice(attrs)))
+	attrSlice := argsToAttrSlice(attrs)
+	if badKey, ok := ctx.Value(contextKeyBadKey).(func(slog.Attr) slog.Attr); ok {
+		attrSlice = replaceBadKeyAttrs(badKey, attrSlice)
+	}
+	return NewContextWithHandler(ctx, handler.WithAttrs(attrSlice))
 }
 
 // ContextWithGroup applies group to a handler stored in ctx.
@@ -179,7 +241,69 @@ func LaxCtxHandler() ctxHandlerOption { //nolint:revive // By design.
 	}
 }
 
+// BadKeyCtxHandler is an option for handling attrs with !BADKEY key,
+// which are result of malformed key/value args passed to logging functions,
+// Logger.With or ContextWithAttrs. Function f is called for each such attr and may
+// return modified attr, zero slog.Attr to drop it, report it somewhere
+// or panic (e.g. in tests, to catch arg mistakes in CI).
+func BadKeyCtxHandler(f func(slog.Attr) slog.Attr) ctxHandlerOption { //nolint:revive // By design.
+	return func(ctxHandler *CtxHandler) {
+		ctxHandler.badKey = f
+	}
+}
+
+// DeadlineCtxHandler is an option for adding attr with key "ctx_deadline_ms"
+// to WARN and ERROR records logged with ctx which has a deadline.
+// Value is amount of milliseconds remaining until deadline
+// (negative if deadline has passed). It helps to diagnose timeouts.
+func DeadlineCtxHandler() ctxHandlerOption { //nolint:revive // By design.
+	return func(ctxHandler *CtxHandler) {
+		ctxHandler.addDeadline = true
+	}
+}
+
+// GoroutineIDCtxHandler is an option for adding attr with key "goroutine"
+// and ID of goroutine which logs a record. See GoroutineID for caveats.
+func GoroutineIDCtxHandler() ctxHandlerOption { //nolint:revive // By design.
+	return func(ctxHandler *CtxHandler) {
+		ctxHandler.addGoroutineID = true
+	}
+}
+
 func (h CtxHandler) withOp(op handlerOp) *CtxHandler {
 	h.ops = append(h.ops[:len(h.ops):len(h.ops)], op) //nolint:revive // By design.
 	return &h
 }
+
+func (h *CtxHandler) replaceBadKey(r slog.Record) slog.Record {
+	found := false
+	r.Attrs(func(a slog.Attr) bool {
+		found = a.Key == badKey
+		return !found
+	})
+	if !found {
+		return r
+	}
+
+	attrs := getAttrs(r.NumAttrs())
+	defer putAttrs(attrs)
+	*attrs = appendRecordAttrs(*attrs, r)
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r2.AddAttrs(replaceBadKeyAttrs(h.badKey, *attrs)...)
+	return r2
+}
+
+// replaceBadKeyAttrs modifies attrs in place.
+func replaceBadKeyAttrs(f func(slog.Attr) slog.Attr, attrs []slog.Attr) []slog.Attr {
+	res := attrs[:0]
+	for _, a := range attrs {
+		if a.Key == badKey {
+			a = f(a)
+			if a.Equal(slog.Attr{}) {
+				continue
+			}
+		}
+		res = append(res, a)
+	}
+	return res
+}
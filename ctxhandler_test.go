@@ -3,9 +3,11 @@ package slogx_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/powerman/check"
 
@@ -120,3 +122,133 @@ func TestLaxCtxHandler(tt *testing.T) {
 	slog.InfoContext(ctx, "some message")
 	t.NotMatch(buf.String(), "!BADCTX")
 }
+
+func TestBadKeyCtxHandler(tt *testing.T) {
+	t := check.T(tt)
+
+	var buf bytes.Buffer
+	var badValues []string
+	badArgs := []any{"key1", "value1", "lonely"}
+	h := slog.NewTextHandler(&buf, nil)
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), h)
+	slog.InfoContext(ctx, "some message", badArgs...)
+	t.Match(buf.String(), `level=INFO msg="some message" key1=value1 !BADKEY=lonely\n$`)
+
+	buf.Reset()
+	ctx = slogx.SetDefaultCtxHandler(context.Background(), h, slogx.BadKeyCtxHandler(func(a slog.Attr) slog.Attr {
+		badValues = append(badValues, a.Value.String())
+		if a.Value.String() == "rename" {
+			return slog.String("renamed", a.Value.String())
+		}
+		return slog.Attr{}
+	}))
+	slog.InfoContext(ctx, "some message", badArgs...)
+	t.Match(buf.String(), `level=INFO msg="some message" key1=value1\n$`)
+
+	buf.Reset()
+	slog.InfoContext(ctx, "some message", "key1", "value1")
+	t.Match(buf.String(), `level=INFO msg="some message" key1=value1\n$`)
+
+	buf.Reset()
+	ctx = slogx.ContextWithAttrs(ctx, "key2", "value2", "rename")
+	slog.InfoContext(ctx, "some message", []any{42, "key3", "value3"}...)
+	t.Match(buf.String(), `level=INFO msg="some message" key2=value2 renamed=rename key3=value3\n$`)
+	t.DeepEqual(badValues, []string{"lonely", "rename", "42"})
+
+	buf.Reset()
+	slog.Default().With(badArgs...).InfoContext(ctx, "some message", "key4", "value4")
+	t.Match(buf.String(), `level=INFO msg="some message" key2=value2 renamed=rename key1=value1 key4=value4\n$`)
+	t.DeepEqual(badValues, []string{"lonely", "rename", "42", "lonely"})
+	t.Equal(slog.Default().Handler().WithAttrs([]slog.Attr{slog.Any("!BADKEY", 1)}), slog.Default().Handler())
+
+	buf.Reset()
+	ctx2 := slogx.ContextWithAttrs(slogx.NewContextWithHandler(context.Background(), h), "lonely")
+	slog.InfoContext(ctx2, "some message")
+	t.Match(buf.String(), `level=INFO msg="some message" !BADKEY=lonely\n$`)
+
+	ctx = slogx.SetDefaultCtxHandler(context.Background(), h, slogx.BadKeyCtxHandler(func(a slog.Attr) slog.Attr {
+		panic(a.String())
+	}))
+	t.PanicMatch(func() { slog.InfoContext(ctx, "some message", badArgs...) }, `!BADKEY=lonely`)
+	t.PanicMatch(func() { slogx.ContextWithAttrs(ctx, "lonely") }, `!BADKEY=lonely`)
+	t.PanicMatch(func() { slog.With("lonely") }, `!BADKEY=lonely`)
+}
+
+func TestDeadlineCtxHandler(tt *testing.T) {
+	t := check.T(tt)
+
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, nil)
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), h)
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	slog.WarnContext(ctx, "some message")
+	t.Match(buf.String(), `level=WARN msg="some message"\n$`)
+
+	buf.Reset()
+	ctx = slogx.SetDefaultCtxHandler(context.Background(), h, slogx.DeadlineCtxHandler())
+	slog.ErrorContext(ctx, "some message")
+	t.Match(buf.String(), `level=ERROR msg="some message"\n$`)
+
+	ctx, cancel = context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	buf.Reset()
+	slog.InfoContext(ctx, "some message")
+	t.Match(buf.String(), `level=INFO msg="some message"\n$`)
+
+	buf.Reset()
+	slog.WarnContext(ctx, "some message")
+	t.Match(buf.String(), `level=WARN msg="some message" ctx_deadline_ms=(59\d\d\d|60000)\n$`)
+
+	ctx, cancel = context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+	buf.Reset()
+	slog.ErrorContext(ctx, "some message")
+	t.Match(buf.String(), `level=ERROR msg="some message" ctx_deadline_ms=-1\d\d\d\n$`)
+}
+
+func TestGoroutineIDCtxHandler(tt *testing.T) {
+	t := check.T(tt)
+
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, nil)
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), h, slogx.GoroutineIDCtxHandler())
+	slog.InfoContext(ctx, "some message")
+	t.HasSuffix(buf.String(), fmt.Sprintf(` level=INFO msg="some message" goroutine=%d`+"\n", slogx.GoroutineID().Value.Uint64()))
+}
+
+func TestContextWithLevel(tt *testing.T) {
+	t := check.T(tt)
+
+	var buf bytes.Buffer
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), slog.NewTextHandler(&buf, nil))
+	log := slog.Default()
+	t.False(log.Enabled(ctx, slog.LevelDebug))
+
+	debugCtx := slogx.ContextWithLevel(ctx, slog.LevelDebug)
+	t.True(log.Enabled(debugCtx, slog.LevelDebug))
+	log.DebugContext(debugCtx, "some message")
+	t.Match(buf.String(), `level=DEBUG msg="some message"\n$`)
+
+	buf.Reset()
+	log.DebugContext(ctx, "some message")
+	t.Equal(buf.String(), "")
+
+	warnCtx := slogx.ContextWithLevel(ctx, slog.LevelWarn)
+	t.False(log.Enabled(warnCtx, slog.LevelInfo))
+	log.InfoContext(warnCtx, "some message")
+	t.Equal(buf.String(), "")
+	t.Nil(log.Handler().Handle(warnCtx, slog.NewRecord(time.Now(), slog.LevelInfo, "some message", 0)))
+	t.Equal(buf.String(), "")
+	log.WarnContext(warnCtx, "some message")
+	t.Match(buf.String(), `level=WARN msg="some message"\n$`)
+
+	buf.Reset()
+	errorCtx := slogx.NewContextWithHandler(ctx, slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelError}))
+	t.False(log.Enabled(errorCtx, slog.LevelWarn))
+	warnCtx = slogx.ContextWithLevel(errorCtx, slog.LevelWarn)
+	t.True(log.Enabled(warnCtx, slog.LevelWarn))
+	t.False(log.Enabled(warnCtx, slog.LevelInfo))
+	log.WarnContext(warnCtx, "some message")
+	t.Match(buf.String(), `level=WARN msg="some message"\n$`)
+}
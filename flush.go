@@ -0,0 +1,42 @@
+package slogx
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Flusher is implemented by handlers which write to io.Writer.
+// Flush flushes the writer if it supports it, e.g. before graceful
+// shutdown or when the writer is wrapped by bufio.Writer.
+type Flusher interface {
+	Flush() error
+}
+
+// Flush walks handler and handlers wrapped by it (like LevelerOf)
+// to find Flusher and flushes it. It returns nil if handler does not
+// provide Flusher.
+func Flush(handler slog.Handler) error {
+	for handler != nil {
+		if h, ok := handler.(Flusher); ok {
+			return h.Flush()
+		}
+		h, ok := handler.(interface{ Unwrap() slog.Handler })
+		if !ok {
+			break
+		}
+		handler = h.Unwrap()
+	}
+	return nil
+}
+
+// flushWriter flushes w if it implements Flush() error (e.g. *bufio.Writer)
+// or Sync() error (e.g. *os.File).
+func flushWriter(w io.Writer) error {
+	switch w := w.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case interface{ Sync() error }:
+		return w.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package slogx_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+type syncWriter struct {
+	bytes.Buffer
+	synced int
+}
+
+func (w *syncWriter) Sync() error {
+	w.synced++
+	return nil
+}
+
+func TestFlush(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	h := slogx.NewRemoteHandler(w, &slogx.RemoteHandlerOptions{FlushLevel: slog.LevelWarn})
+	log := slog.New(slogx.NewSlowHandler(h, nopHandler{}, 0))
+	log.Info("first")
+	t.Equal(buf.Len(), 0)
+	log.Warn("second")
+	n := buf.Len()
+	t.NotZero(n)
+	log.Info("third")
+	t.Equal(buf.Len(), n)
+	t.Nil(slogx.Flush(log.Handler()))
+	t.Greater(buf.Len(), n)
+
+	var out bytes.Buffer
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &buf, slog.NewTextHandler(&out, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+	t.Equal(out.String(), "level=INFO msg=first\nlevel=WARN msg=second\nlevel=INFO msg=third\n")
+
+	sw := &syncWriter{}
+	log = slog.New(slogx.NewRemoteHandler(sw, &slogx.RemoteHandlerOptions{FlushLevel: slog.LevelError}))
+	log.Warn("first")
+	t.Equal(sw.synced, 0)
+	log.Error("second")
+	t.Equal(sw.synced, 1)
+	t.Nil(slogx.Flush(log.Handler()))
+	t.Equal(sw.synced, 2)
+
+	buf.Reset()
+	w = bufio.NewWriter(&buf)
+	tmpl := template.Must(template.New("").Parse(`{{.Level}} {{.Message}}`))
+	log = slog.New(slogx.NewTemplateHandler(w, tmpl, &slogx.TemplateHandlerOptions{FlushLevel: slog.LevelWarn}))
+	log.Info("first")
+	t.Equal(buf.String(), "")
+	log.Warn("second")
+	t.Equal(buf.String(), "INFO first\nWARN second\n")
+	log.Info("third")
+	t.Equal(buf.String(), "INFO first\nWARN second\n")
+	t.Nil(slogx.Flush(log.Handler()))
+	t.Equal(buf.String(), "INFO first\nWARN second\nINFO third\n")
+
+	t.Nil(slogx.Flush(slog.NewTextHandler(io.Discard, nil)))
+	t.Nil(slogx.Flush(slogx.NewRemoteHandler(io.Discard, nil)))
+}
+
+func TestOnWriteError(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var msgs []string
+	var errs []error
+	onWriteError := func(r slog.Record, err error) {
+		msgs = append(msgs, r.Message)
+		errs = append(errs, err)
+	}
+
+	log := slog.New(slogx.NewRemoteHandler(errWriter{}, &slogx.RemoteHandlerOptions{OnWriteError: onWriteError}))
+	log.Info("first")
+	t.Err(log.Handler().Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "second", 0)), io.ErrShortWrite)
+
+	tmpl := template.Must(template.New("").Parse(`{{.Message}}`))
+	log = slog.New(slogx.NewTemplateHandler(errWriter{}, tmpl, &slogx.TemplateHandlerOptions{OnWriteError: onWriteError}))
+	log.Info("third")
+
+	t.DeepEqual(msgs, []string{"first", "second", "third"})
+	t.DeepEqual(errs, []error{io.ErrShortWrite, io.ErrShortWrite, io.ErrShortWrite})
+
+	tmpl = template.Must(template.New("").Parse(`{{.Message.Bad}}`))
+	log = slog.New(slogx.NewTemplateHandler(io.Discard, tmpl, &slogx.TemplateHandlerOptions{OnWriteError: onWriteError}))
+	log.Info("template error")
+	t.Len(msgs, 3)
+}
@@ -1,6 +1,6 @@
 module github.com/powerman/slogx
 
-go 1.22
+go 1.23
 
 require (
 	github.com/powerman/check v1.7.0
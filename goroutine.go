@@ -0,0 +1,32 @@
+package slogx
+
+import (
+	"bytes"
+	"log/slog"
+	"runtime"
+	"strconv"
+)
+
+// KeyGoroutineID is a key of attr returned by GoroutineID.
+const KeyGoroutineID = "goroutine"
+
+// GoroutineID returns an attr with numeric ID of current goroutine.
+//
+// Go intentionally does not provide goroutine IDs, so it is extracted from
+// a header of a stack trace. This costs about as much as formatting
+// a record, so use it only for debugging (e.g. to correlate interleaved
+// lines of concurrent workers in dev logs) and never use ID for anything
+// except logging.
+func GoroutineID() slog.Attr {
+	return slog.Uint64(KeyGoroutineID, goroutineID())
+}
+
+func goroutineID() uint64 {
+	const size = 64
+	var buf [size]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	b, _, _ = bytes.Cut(b, []byte(" "))
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
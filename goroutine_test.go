@@ -0,0 +1,25 @@
+package slogx_test
+
+import (
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestGoroutineID(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	id := slogx.GoroutineID()
+	t.Equal(id.Key, slogx.KeyGoroutineID)
+	t.NotZero(id.Value.Uint64())
+	t.Equal(slogx.GoroutineID().Value.Uint64(), id.Value.Uint64())
+
+	ch := make(chan uint64)
+	go func() { ch <- slogx.GoroutineID().Value.Uint64() }()
+	other := <-ch
+	t.NotZero(other)
+	t.NotEqual(other, id.Value.Uint64())
+}
@@ -5,16 +5,44 @@ import (
 	"strings"
 )
 
+// Extra levels matching syslog severities which have no slog.Level.
+const (
+	LevelNotice    = slog.LevelInfo + 2
+	LevelCritical  = slog.LevelError + 4
+	LevelAlert     = slog.LevelError + 8
+	LevelEmergency = slog.LevelError + 12
+)
+
+// Syslog severities (RFC 5424).
+const (
+	SeverityEmergency = iota
+	SeverityAlert
+	SeverityCritical
+	SeverityError
+	SeverityWarning
+	SeverityNotice
+	SeverityInfo
+	SeverityDebug
+)
+
 // ParseLevel converts log level name into slog.Level.
 // It is case insensitive, ignores surrounding spaces
 // and accepts shortened level name. In case of unknown
 // log level name it will return slog.LevelDebug.
 func ParseLevel(levelName string) slog.Level {
 	switch strings.ToLower(strings.TrimSpace(levelName)) {
+	case "emerg", "emergency":
+		return LevelEmergency
+	case "alert":
+		return LevelAlert
+	case "crit", "critical":
+		return LevelCritical
 	case "err", "error":
 		return slog.LevelError
 	case "wrn", "warn", "warning":
 		return slog.LevelWarn
+	case "notice":
+		return LevelNotice
 	case "inf", "info":
 		return slog.LevelInfo
 	case "dbg", "debug":
@@ -24,3 +52,59 @@ func ParseLevel(levelName string) slog.Level {
 		return slog.LevelDebug
 	}
 }
+
+// SyslogSeverity converts slog.Level into syslog severity.
+// Levels between standard and extra levels defined by this package
+// are rounded down, e.g. slog.LevelWarn+1 results in SeverityWarning.
+func SyslogSeverity(level slog.Level) int {
+	switch {
+	case level >= LevelEmergency:
+		return SeverityEmergency
+	case level >= LevelAlert:
+		return SeverityAlert
+	case level >= LevelCritical:
+		return SeverityCritical
+	case level >= slog.LevelError:
+		return SeverityError
+	case level >= slog.LevelWarn:
+		return SeverityWarning
+	case level >= LevelNotice:
+		return SeverityNotice
+	case level >= slog.LevelInfo:
+		return SeverityInfo
+	default:
+		return SeverityDebug
+	}
+}
+
+// VerbosityLevel converts verbosity n into slog.Level.
+// It is useful for CLI tools where n is usually calculated as amount of
+// -v flags minus amount of -q flags: n=0 returns slog.LevelInfo, n=1 returns
+// slog.LevelDebug, n=-1 returns slog.LevelWarn, n=-2 returns slog.LevelError.
+// Each extra step shifts level by 4, e.g. n=2 returns slog.LevelDebug-4.
+func VerbosityLevel(n int) slog.Level {
+	const step = slog.LevelWarn - slog.LevelInfo
+	return slog.LevelInfo - slog.Level(n)*step
+}
+
+// LevelerOf walks handler and handlers wrapped by it to find
+// slog.Leveler (e.g. *slog.LevelVar) which controls minimal level.
+// It returns false if handler does not provide it.
+//
+// Handler provides slog.Leveler by implementing method
+// Leveler() slog.Leveler. Handler wrapping another handler should
+// implement method Unwrap() slog.Handler to make LevelerOf look
+// inside it. Handlers in this package implement these methods.
+func LevelerOf(handler slog.Handler) (slog.Leveler, bool) {
+	for handler != nil {
+		if h, ok := handler.(interface{ Leveler() slog.Leveler }); ok {
+			return h.Leveler(), true
+		}
+		h, ok := handler.(interface{ Unwrap() slog.Handler })
+		if !ok {
+			break
+		}
+		handler = h.Unwrap()
+	}
+	return nil, false
+}
@@ -1,8 +1,11 @@
 package slogx_test
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/powerman/check"
 
@@ -17,11 +20,17 @@ func TestParseLevel(tt *testing.T) {
 		levelName string
 		want      slog.Level
 	}{
+		{"emerg", slogx.LevelEmergency},
+		{"Emergency", slogx.LevelEmergency},
+		{"alert", slogx.LevelAlert},
+		{"crit", slogx.LevelCritical},
+		{"critical", slogx.LevelCritical},
 		{"Err", slog.LevelError},
 		{"error ", slog.LevelError},
 		{" wrn", slog.LevelWarn},
 		{" warn ", slog.LevelWarn},
 		{"warning", slog.LevelWarn},
+		{"notice", slogx.LevelNotice},
 		{"inf", slog.LevelInfo},
 		{"info", slog.LevelInfo},
 		{"dbg", slog.LevelDebug},
@@ -37,3 +46,90 @@ func TestParseLevel(tt *testing.T) {
 		})
 	}
 }
+
+func TestSyslogSeverity(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	tests := []struct {
+		level slog.Level
+		want  int
+	}{
+		{slog.LevelDebug - 4, slogx.SeverityDebug},
+		{slog.LevelDebug, slogx.SeverityDebug},
+		{slog.LevelInfo, slogx.SeverityInfo},
+		{slog.LevelInfo + 1, slogx.SeverityInfo},
+		{slogx.LevelNotice, slogx.SeverityNotice},
+		{slog.LevelWarn, slogx.SeverityWarning},
+		{slog.LevelWarn + 1, slogx.SeverityWarning},
+		{slog.LevelError, slogx.SeverityError},
+		{slogx.LevelCritical, slogx.SeverityCritical},
+		{slogx.LevelAlert, slogx.SeverityAlert},
+		{slogx.LevelEmergency, slogx.SeverityEmergency},
+		{slogx.LevelEmergency + 4, slogx.SeverityEmergency},
+	}
+
+	for _, tc := range tests {
+		t.Run("", func(tt *testing.T) {
+			t := check.T(tt).MustAll()
+			t.Equal(slogx.SyslogSeverity(tc.level), tc.want)
+		})
+	}
+}
+
+func TestVerbosityLevel(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	tests := []struct {
+		n    int
+		want slog.Level
+	}{
+		{-3, slog.LevelError + 4},
+		{-2, slog.LevelError},
+		{-1, slog.LevelWarn},
+		{0, slog.LevelInfo},
+		{1, slog.LevelDebug},
+		{2, slog.LevelDebug - 4},
+	}
+
+	for _, tc := range tests {
+		t.Run("", func(tt *testing.T) {
+			t := check.T(tt).MustAll()
+			t.Equal(slogx.VerbosityLevel(tc.n), tc.want)
+		})
+	}
+}
+
+func TestLevelerOf(tt *testing.T) {
+	t := check.T(tt)
+
+	l, ok := slogx.LevelerOf(nil)
+	t.False(ok)
+	t.Nil(l)
+
+	text := slog.NewTextHandler(io.Discard, nil)
+	l, ok = slogx.LevelerOf(text)
+	t.False(ok)
+	t.Nil(l)
+
+	var level slog.LevelVar
+	remote := slogx.NewRemoteHandler(io.Discard, &slogx.RemoteHandlerOptions{Level: &level})
+	l, ok = slogx.LevelerOf(remote)
+	t.True(ok)
+	t.Equal(l, &level)
+
+	slow := slogx.NewSlowHandler(remote, text, time.Second)
+	l, ok = slogx.LevelerOf(slow.WithGroup("g"))
+	t.True(ok)
+	t.Equal(l, &level)
+
+	slogx.SetDefaultCtxHandler(context.Background(), slow)
+	l, ok = slogx.LevelerOf(slog.Default().Handler())
+	t.True(ok)
+	t.Equal(l, &level)
+
+	l, ok = slogx.LevelerOf(slogx.NewSlowHandler(text, text, time.Second))
+	t.False(ok)
+	t.Nil(l)
+}
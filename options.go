@@ -0,0 +1,27 @@
+package slogx
+
+import "time"
+
+// handlerOption is an option for handlers which use current time:
+// SlowHandler, SheddingHandler, StatsHandler and RequireContextHandler.
+type handlerOption func(*handlerOptions)
+
+type handlerOptions struct {
+	now func() time.Time
+}
+
+func newHandlerOptions(opts []handlerOption) handlerOptions {
+	o := handlerOptions{now: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ClockOption is an option for using now instead of time.Now to get
+// current time, e.g. to make output and behaviour deterministic in tests.
+func ClockOption(now func() time.Time) handlerOption { //nolint:revive // By design.
+	return func(o *handlerOptions) {
+		o.now = now
+	}
+}
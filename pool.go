@@ -0,0 +1,51 @@
+package slogx
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// Size classes of pooled attr slices. Larger slices are not pooled.
+var attrPoolSizes = [...]int{8, 32, 128} //nolint:gochecknoglobals // Const.
+
+//nolint:gochecknoglobals // Pools must be shared.
+var attrPools = [len(attrPoolSizes)]sync.Pool{
+	{New: func() any { return newAttrs(attrPoolSizes[0]) }},
+	{New: func() any { return newAttrs(attrPoolSizes[1]) }},
+	{New: func() any { return newAttrs(attrPoolSizes[2]) }},
+}
+
+func newAttrs(size int) *[]slog.Attr {
+	attrs := make([]slog.Attr, 0, size)
+	return &attrs
+}
+
+// getAttrs returns empty slice with capacity at least n.
+// It should be returned using putAttrs when no longer used.
+func getAttrs(n int) *[]slog.Attr {
+	for i, size := range attrPoolSizes {
+		if n <= size {
+			return attrPools[i].Get().(*[]slog.Attr) //nolint:forcetypeassert // By design.
+		}
+	}
+	return newAttrs(n)
+}
+
+func putAttrs(attrs *[]slog.Attr) {
+	clear(*attrs)
+	*attrs = (*attrs)[:0]
+	for i, size := range attrPoolSizes {
+		if cap(*attrs) == size {
+			attrPools[i].Put(attrs)
+			return
+		}
+	}
+}
+
+func appendRecordAttrs(attrs []slog.Attr, r slog.Record) []slog.Attr {
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
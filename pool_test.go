@@ -0,0 +1,54 @@
+package slogx_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestWrapperAllocs(tt *testing.T) {
+	t := check.T(tt)
+	if raceEnabled {
+		t.Skip("race detector randomly drops pooled items")
+	}
+
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	r.AddAttrs(slog.String("app", "test"), slog.Int("id", 42), slog.Bool("ok", true))
+	badKeyRecord := r.Clone()
+	badKeyRecord.AddAttrs(slog.String("!BADKEY", "lonely"))
+	schema := slogx.Schema{"app": slog.KindString, "id": slog.KindInt64, "ok": slog.KindBool, "req": slog.KindInt64}
+	dropBadKey := slogx.BadKeyCtxHandler(func(slog.Attr) slog.Attr { return slog.Attr{} })
+
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), nopHandler{}, dropBadKey)
+	ctx = slogx.ContextWithAttrs(ctx, "req", 1)
+	ctxHandler := slog.Default().Handler()
+
+	schemaHandler := slogx.NewSchemaHandler(nopHandler{}, schema, slogx.DropSchemaHandler())
+	schemaCtx := slogx.SetDefaultCtxHandler(context.Background(), schemaHandler, dropBadKey)
+	schemaCtx = slogx.ContextWithAttrs(schemaCtx, "req", 1)
+	schemaCtxHandler := slog.Default().Handler()
+
+	tests := map[string]struct {
+		h   slog.Handler
+		ctx context.Context
+		r   slog.Record
+	}{
+		"SchemaHandler":                  {schemaHandler, context.Background(), r},
+		"CtxHandler":                     {ctxHandler, ctx, r},
+		"CtxHandler with bad key":        {ctxHandler, ctx, badKeyRecord},
+		"CtxHandler+Schema":              {schemaCtxHandler, schemaCtx, r},
+		"CtxHandler+Schema with bad key": {schemaCtxHandler, schemaCtx, badKeyRecord},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(tt *testing.T) {
+			t := check.T(tt)
+			allocs := testing.AllocsPerRun(100, func() { _ = tc.h.Handle(tc.ctx, tc.r) })
+			t.Zero(allocs)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package slogx
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+const clearLine = "\r\x1b[K"
+
+// ProgressWriter is an io.Writer which makes log output cooperate with
+// interactive terminal progress bar or spinner drawn on the current line.
+//
+// Progress line should be drawn using SetProgress instead of writing it
+// directly to the terminal. Each Write will clear current progress line,
+// output written data (expected to be one or more complete lines) and
+// redraw progress line below it, so log records and progress output
+// won't scramble each other.
+//
+// ProgressWriter is safe for concurrent use.
+type ProgressWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	progress string
+	buf      []byte
+}
+
+// NewProgressWriter creates a ProgressWriter which outputs to w
+// (usually a terminal, e.g. os.Stderr).
+func NewProgressWriter(w io.Writer) *ProgressWriter {
+	return &ProgressWriter{w: w}
+}
+
+// Write implements io.Writer.
+func (pw *ProgressWriter) Write(p []byte) (int, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	pw.buf = pw.buf[:0]
+	if pw.progress != "" {
+		pw.buf = append(pw.buf, clearLine...)
+	}
+	pw.buf = append(pw.buf, p...)
+	pw.buf = append(pw.buf, pw.progress...)
+	_, err := pw.w.Write(pw.buf)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// SetProgress replaces current progress line with line.
+// Empty line removes progress line.
+// Line must not contain "\n", all "\n" in line will be replaced by spaces.
+func (pw *ProgressWriter) SetProgress(line string) error {
+	line = strings.ReplaceAll(line, "\n", " ")
+
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+
+	if line == pw.progress {
+		return nil
+	}
+	pw.buf = append(pw.buf[:0], clearLine...)
+	pw.buf = append(pw.buf, line...)
+	pw.progress = line
+	_, err := pw.w.Write(pw.buf)
+	return err
+}
@@ -0,0 +1,52 @@
+package slogx_test
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestProgressWriter(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var buf bytes.Buffer
+	pw := slogx.NewProgressWriter(&buf)
+	log := slog.New(slog.NewTextHandler(pw, &slog.HandlerOptions{
+		ReplaceAttr: dropTime,
+	}))
+
+	log.Info("first")
+	t.Equal(buf.String(), "level=INFO msg=first\n")
+
+	buf.Reset()
+	t.Nil(pw.SetProgress("10%\n"))
+	t.Equal(buf.String(), "\r\x1b[K10% ")
+
+	buf.Reset()
+	t.Nil(pw.SetProgress("10% "))
+	t.Len(buf.String(), 0)
+
+	buf.Reset()
+	log.Info("second")
+	t.Equal(buf.String(), "\r\x1b[Klevel=INFO msg=second\n10% ")
+
+	buf.Reset()
+	t.Nil(pw.SetProgress(""))
+	t.Equal(buf.String(), "\r\x1b[K")
+
+	buf.Reset()
+	log.Info("third")
+	t.Equal(buf.String(), "level=INFO msg=third\n")
+
+	pw = slogx.NewProgressWriter(errWriter{})
+	n, err := pw.Write([]byte("line\n"))
+	t.Err(err, io.ErrShortWrite)
+	t.Zero(n)
+	t.Err(pw.SetProgress("10%"), io.ErrShortWrite)
+}
@@ -0,0 +1,130 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// KeyProvenance is a key of attr added by ProvenanceHandler.
+const KeyProvenance = "!PROVENANCE"
+
+const (
+	provenanceMaxDepth  = 32
+	provenancePathElems = 2
+)
+
+// ProvenanceHandler is a debugging tool which helps to answer
+// "who added this attr?" in complex pipelines.
+//
+// It adds to each record a group attr with key "!PROVENANCE" which contains
+// an attr for each attr of the record with same (qualified by groups) key
+// and "file:line" value pointing to the code which added the attr:
+// either the call of slog.Logger.With, ContextWithAttrs, etc.
+// (i.e. the first caller of WithAttrs outside of log/slog and this package)
+// or the code which logged the record.
+//
+// Provenance group is not a part of groups added by WithGroup, so its
+// keys are qualified by all groups, e.g. "!PROVENANCE.g.a". To add it
+// outside of groups every record handled after WithGroup is passed to
+// a handler created by replaying WithAttrs and WithGroup calls, which is
+// slow, but this handler is a debugging tool anyway.
+//
+// It should be used to wrap a handler stored in a context or
+// a fallback handler of CtxHandler. Note that CtxHandler applies attrs
+// added by slog.Logger.With while handling a record, so origin of such attrs
+// will be the code which logged the record.
+type ProvenanceHandler struct {
+	wrapHandler
+	outside outsideGroups
+	origins []slog.Attr
+	prefix  string
+}
+
+// NewProvenanceHandler creates a ProvenanceHandler which wraps next handler.
+func NewProvenanceHandler(next slog.Handler) *ProvenanceHandler {
+	return &ProvenanceHandler{
+		wrapHandler: wrapHandler{next: next},
+		outside:     outsideGroups{root: next},
+	}
+}
+
+// Handle implements slog.Handler interface.
+func (h *ProvenanceHandler) Handle(ctx context.Context, r slog.Record) error {
+	origin := "unknown"
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		origin = frameOrigin(frame)
+	}
+	origins := slices.Clip(h.origins)
+	r.Attrs(func(a slog.Attr) bool {
+		origins = appendOrigins(origins, h.prefix, a, origin)
+		return true
+	})
+	provenance := slog.Attr{Key: KeyProvenance, Value: slog.GroupValue(origins...)}
+
+	if !h.outside.grouped() {
+		r = r.Clone()
+		r.AddAttrs(provenance)
+		return h.next.Handle(ctx, r)
+	}
+	return h.outside.handler(provenance).Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *ProvenanceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	origin := callerOrigin()
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.outside = h.outside.withAttrs(h2.next, attrs)
+	h2.origins = slices.Clip(h.origins)
+	for _, a := range attrs {
+		h2.origins = appendOrigins(h2.origins, h.prefix, a, origin)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *ProvenanceHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.outside = h.outside.withGroup(name)
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func appendOrigins(origins []slog.Attr, prefix string, a slog.Attr, origin string) []slog.Attr {
+	for _, fa := range appendFlatAttr(nil, prefix, a) {
+		origins = append(origins, slog.String(fa.Key, origin))
+	}
+	return origins
+}
+
+func callerOrigin() string {
+	const skip = 3 // Skip runtime.Callers, callerOrigin and WithAttrs.
+	var pcs [provenanceMaxDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "log/slog.") &&
+			!strings.HasPrefix(frame.Function, "github.com/powerman/slogx.") {
+			return frameOrigin(frame)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+func frameOrigin(frame runtime.Frame) string {
+	return lastPathElems(frame.File, provenancePathElems) + ":" + strconv.Itoa(frame.Line)
+}
@@ -0,0 +1,51 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestProvenanceHandler(tt *testing.T) {
+	t := check.T(tt)
+
+	var buf bytes.Buffer
+	next := slog.NewTextHandler(&buf, nil)
+	h := slogx.NewProvenanceHandler(next)
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+	t.False(h.Enabled(context.Background(), slog.LevelDebug))
+
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), h)
+	ctx = slogx.ContextWithAttrs(ctx, "app", "test")
+	log := slog.New(h).With("user", "bob").WithGroup("g")
+	log.InfoContext(ctx, "msg", "a", 1, slog.Group("sub", "b", 2, "c", 3))
+	t.Match(buf.String(), `msg=msg user=bob `+
+		`!PROVENANCE.user=\S*/provenance_test.go:26 `+
+		`!PROVENANCE.g.a=\S*/provenance_test.go:27 `+
+		`!PROVENANCE.g.sub.b=\S*/provenance_test.go:27 `+
+		`!PROVENANCE.g.sub.c=\S*/provenance_test.go:27 `+
+		`g.a=1 g.sub.b=2 g.sub.c=3\n$`)
+
+	buf.Reset()
+	log.With("d", 4).InfoContext(ctx, "msg")
+	t.Match(buf.String(), `msg=msg user=bob `+
+		`!PROVENANCE.user=\S*/provenance_test.go:26 `+
+		`!PROVENANCE.g.d=\S*/provenance_test.go:36 `+
+		`g.d=4\n$`)
+
+	buf.Reset()
+	slog.InfoContext(ctx, "msg")
+	t.Match(buf.String(), `msg=msg app=test !PROVENANCE.app=\S*/provenance_test.go:25\n$`)
+
+	buf.Reset()
+	r := slog.Record{Message: "msg"}
+	r.AddAttrs(slog.Int("a", 1))
+	t.Nil(h.Handle(context.Background(), r))
+	t.Match(buf.String(), `msg=msg a=1 !PROVENANCE.a=unknown\n$`)
+}
@@ -0,0 +1,309 @@
+package slogx
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+)
+
+// RemoteHandler forwards records to a handler in another process,
+// e.g. from a plugin binary to the host process's handler, using a simple
+// stream protocol over w (pipe, unix socket, etc.). The host process
+// should read this stream using ServeRemoteHandler.
+//
+// Attrs and groups added using WithAttrs and WithGroup (including those
+// added to context by ContextWithAttrs and ContextWithGroup when
+// RemoteHandler is used with CtxHandler) are forwarded with each record and
+// applied to the host's handler, so records will have same layout as
+// records logged by the host itself.
+//
+// Values of kind slog.KindAny are forwarded as strings formatted by fmt.
+// Source location of records is not forwarded.
+//
+// RemoteHandler implements Flusher.
+// Output may be duplicated to another writer using TeeOutput.
+type RemoteHandler struct {
+	conn  *remoteConn
+	level slog.Leveler
+	ops   []remoteOp
+}
+
+// RemoteHandlerOptions are options for a RemoteHandler.
+type RemoteHandlerOptions struct {
+	// Level reports the minimum record level that will be forwarded.
+	// If nil, the handler assumes slog.LevelInfo.
+	Level slog.Leveler
+	// FlushLevel makes handler flush writer (see Flush) after writing
+	// records at or above this level. If nil, writer is not flushed.
+	FlushLevel slog.Leveler
+	// OnWriteError is called with a record and an error returned by
+	// writer (or its flush), because slog.Logger ignores errors returned
+	// by Handle. It may count failures, log record using another handler
+	// (e.g. to stderr), or panic. Handle still returns the error after
+	// OnWriteError returns.
+	OnWriteError func(slog.Record, error)
+}
+
+type remoteConn struct {
+	mu           sync.Mutex
+	w            io.Writer
+	enc          *gob.Encoder
+	flushLevel   slog.Leveler
+	onWriteError func(slog.Record, error)
+	tees         teeWriters[*remoteTee]
+}
+
+// remoteTee encodes a separate stream for a tee writer.
+type remoteTee struct {
+	buf bytes.Buffer
+	enc *gob.Encoder
+}
+
+type remoteRecord struct {
+	Ops   []remoteOp
+	Time  time.Time
+	Level slog.Level
+	Msg   string
+	Attrs []remoteAttr
+}
+
+type remoteOp struct {
+	Group string
+	Attrs []remoteAttr
+}
+
+type remoteAttr struct {
+	Key   string
+	Kind  slog.Kind
+	Str   string
+	Num   uint64
+	Time  time.Time
+	Group []remoteAttr
+}
+
+// NewRemoteHandler creates a RemoteHandler which writes records to w,
+// using the given options.
+// If opts is nil, the default options are used.
+func NewRemoteHandler(w io.Writer, opts *RemoteHandlerOptions) *RemoteHandler {
+	if opts == nil {
+		opts = &RemoteHandlerOptions{}
+	}
+	level := opts.Level
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &RemoteHandler{
+		conn: &remoteConn{
+			w:            w,
+			enc:          gob.NewEncoder(w),
+			flushLevel:   opts.FlushLevel,
+			onWriteError: opts.OnWriteError,
+			tees:         make(teeWriters[*remoteTee]),
+		},
+		level: level,
+	}
+}
+
+// Enabled implements slog.Handler interface.
+func (h *RemoteHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= h.level.Level()
+}
+
+// Handle implements slog.Handler interface.
+func (h *RemoteHandler) Handle(_ context.Context, r slog.Record) error {
+	rr := remoteRecord{
+		Ops:   h.ops,
+		Time:  r.Time,
+		Level: r.Level,
+		Msg:   r.Message,
+		Attrs: make([]remoteAttr, 0, r.NumAttrs()),
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		rr.Attrs = appendRemoteAttr(rr.Attrs, a)
+		return true
+	})
+
+	err := h.conn.write(rr)
+	if err != nil && h.conn.onWriteError != nil {
+		h.conn.onWriteError(r, err)
+	}
+	return err
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *RemoteHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	ras := toRemoteAttrs(attrs)
+	if len(ras) == 0 {
+		return h
+	}
+	return h.withOp(remoteOp{Attrs: ras})
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *RemoteHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withOp(remoteOp{Group: name})
+}
+
+func (conn *remoteConn) write(rr remoteRecord) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	err := conn.enc.Encode(rr)
+	if err == nil && conn.flushLevel != nil && rr.Level >= conn.flushLevel.Level() {
+		err = flushWriter(conn.w)
+	}
+	for tw, tee := range conn.tees {
+		tee.buf.Reset()
+		if tee.enc.Encode(rr) != nil || !tw.send(bytes.Clone(tee.buf.Bytes())) {
+			conn.tees.detach(tw)
+		}
+	}
+	return err
+}
+
+func (h *RemoteHandler) teeOutput(w io.Writer) func() {
+	tee := &remoteTee{}
+	tee.enc = gob.NewEncoder(&tee.buf)
+	return h.conn.tees.add(&h.conn.mu, newTeeWriter(w), tee)
+}
+
+// Flush implements Flusher interface.
+// It flushes w given to NewRemoteHandler if it implements Flush() error
+// (e.g. *bufio.Writer) or Sync() error (e.g. *os.File).
+func (h *RemoteHandler) Flush() error {
+	h.conn.mu.Lock()
+	defer h.conn.mu.Unlock()
+	return flushWriter(h.conn.w)
+}
+
+// Leveler returns level configured in RemoteHandlerOptions.
+func (h *RemoteHandler) Leveler() slog.Leveler {
+	return h.level
+}
+
+func (h RemoteHandler) withOp(op remoteOp) *RemoteHandler {
+	h.ops = append(h.ops[:len(h.ops):len(h.ops)], op) //nolint:revive // By design.
+	return &h
+}
+
+// ServeRemoteHandler reads records written by RemoteHandler from r
+// and handles them using handler until r returns io.EOF.
+// Records not enabled by handler are skipped.
+// It stops and returns error returned by handler's Handle.
+func ServeRemoteHandler(ctx context.Context, r io.Reader, handler slog.Handler) error {
+	dec := gob.NewDecoder(r)
+	for {
+		var rr remoteRecord
+		err := dec.Decode(&rr)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("decode remote record: %w", err)
+		}
+
+		h := handler
+		for _, op := range rr.Ops {
+			if op.Group != "" {
+				h = h.WithGroup(op.Group)
+			} else {
+				h = h.WithAttrs(fromRemoteAttrs(op.Attrs))
+			}
+		}
+		if !h.Enabled(ctx, rr.Level) {
+			continue
+		}
+		rec := slog.NewRecord(rr.Time, rr.Level, rr.Msg, 0)
+		rec.AddAttrs(fromRemoteAttrs(rr.Attrs)...)
+		err = h.Handle(ctx, rec)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func toRemoteAttrs(attrs []slog.Attr) []remoteAttr {
+	ras := make([]remoteAttr, 0, len(attrs))
+	for _, a := range attrs {
+		ras = appendRemoteAttr(ras, a)
+	}
+	return ras
+}
+
+// appendRemoteAttr appends a to ras unless a is empty or an empty group.
+func appendRemoteAttr(ras []remoteAttr, a slog.Attr) []remoteAttr {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return ras
+	}
+	v := a.Value
+	ra := remoteAttr{Key: a.Key, Kind: v.Kind()}
+	switch v.Kind() {
+	case slog.KindString:
+		ra.Str = v.String()
+	case slog.KindInt64:
+		ra.Num = uint64(v.Int64())
+	case slog.KindUint64:
+		ra.Num = v.Uint64()
+	case slog.KindFloat64:
+		ra.Num = math.Float64bits(v.Float64())
+	case slog.KindBool:
+		if v.Bool() {
+			ra.Num = 1
+		}
+	case slog.KindDuration:
+		ra.Num = uint64(v.Duration())
+	case slog.KindTime:
+		ra.Time = v.Time()
+	case slog.KindGroup:
+		ra.Group = toRemoteAttrs(v.Group())
+		if len(ra.Group) == 0 {
+			return ras
+		}
+	case slog.KindAny, slog.KindLogValuer:
+		ra.Kind = slog.KindString
+		ra.Str = fmt.Sprint(v.Any())
+	}
+	return append(ras, ra)
+}
+
+func fromRemoteAttrs(ras []remoteAttr) []slog.Attr {
+	attrs := make([]slog.Attr, len(ras))
+	for i := range ras {
+		attrs[i] = fromRemoteAttr(ras[i])
+	}
+	return attrs
+}
+
+func fromRemoteAttr(ra remoteAttr) slog.Attr {
+	a := slog.Attr{Key: ra.Key}
+	switch ra.Kind {
+	case slog.KindString:
+		a.Value = slog.StringValue(ra.Str)
+	case slog.KindInt64:
+		a.Value = slog.Int64Value(int64(ra.Num))
+	case slog.KindUint64:
+		a.Value = slog.Uint64Value(ra.Num)
+	case slog.KindFloat64:
+		a.Value = slog.Float64Value(math.Float64frombits(ra.Num))
+	case slog.KindBool:
+		a.Value = slog.BoolValue(ra.Num != 0)
+	case slog.KindDuration:
+		a.Value = slog.DurationValue(time.Duration(ra.Num))
+	case slog.KindTime:
+		a.Value = slog.TimeValue(ra.Time)
+	case slog.KindGroup:
+		a.Value = slog.GroupValue(fromRemoteAttrs(ra.Group)...)
+	case slog.KindAny, slog.KindLogValuer:
+		a.Value = slog.AnyValue(ra.Str)
+	}
+	return a
+}
@@ -0,0 +1,92 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/powerman/check"
+	"go.uber.org/mock/gomock"
+
+	"github.com/powerman/slogx"
+)
+
+func TestRemoteHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var stream, buf bytes.Buffer
+	remote := slogx.NewRemoteHandler(&stream, nil)
+	t.True(remote.Enabled(context.Background(), slog.LevelInfo))
+	t.False(remote.Enabled(context.Background(), slog.LevelDebug))
+	checkWithNoop(t, remote)
+
+	log := slog.New(remote)
+	log.Debug("skipped")
+	log.Info("first", "str", "s", "int", -1, "uint", uint(2), "float", 0.5, "bool", true,
+		"dur", time.Second, "time", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"any", io.EOF, slog.Group("g", "a", 1, "b", 2))
+	log.With("app", "plugin").WithGroup("req").With("id", 42).Warn("second", "status", 200)
+
+	h := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level:       slog.LevelWarn,
+		ReplaceAttr: dropTime,
+	})
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &stream, h.WithAttrs([]slog.Attr{slog.String("host", "yes")})))
+	t.Equal(buf.String(), "level=WARN msg=second host=yes app=plugin req.id=42 req.status=200\n")
+
+	stream.Reset()
+	buf.Reset()
+	log = slog.New(slogx.NewRemoteHandler(&stream, nil))
+	log.Info("first", "str", "s", "int", -1, "uint", uint(2), "float", 0.5, "bool", true,
+		"dur", time.Second, "time", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"any", io.EOF, slog.Group("g", "a", 1, "b", 2))
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &stream, slog.NewTextHandler(&buf, nil)))
+	t.Match(buf.String(), `^time=\S+ level=INFO msg=first str=s int=-1 uint=2 float=0.5 bool=true dur=1s time=2024-01-02T03:04:05.000Z any=EOF g.a=1 g.b=2\n$`)
+
+	t.Match(slogx.ServeRemoteHandler(context.Background(), bytes.NewReader([]byte("bad")), h), "decode remote record")
+}
+
+func TestServeRemoteHandlerErr(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	var stream bytes.Buffer
+	slog.New(slogx.NewRemoteHandler(&stream, &slogx.RemoteHandlerOptions{Level: slog.LevelDebug})).Debug("first")
+
+	h := NewMockHandler(ctrl)
+	h.EXPECT().Enabled(context.Background(), slog.LevelDebug).Return(true)
+	h.EXPECT().Handle(context.Background(), gomock.Any()).Return(io.EOF)
+	t.Err(slogx.ServeRemoteHandler(context.Background(), &stream, h), io.EOF)
+}
+
+func TestRemoteHandlerSlogtest(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var stream bytes.Buffer
+	t.Nil(slogtest.TestHandler(slogx.NewRemoteHandler(&stream, nil), func() []map[string]any {
+		var buf bytes.Buffer
+		t.Nil(slogx.ServeRemoteHandler(context.Background(), &stream, slog.NewJSONHandler(&buf, nil)))
+		var ms []map[string]any
+		for dec := json.NewDecoder(&buf); dec.More(); {
+			var m map[string]any
+			t.Nil(dec.Decode(&m))
+			ms = append(ms, m)
+		}
+		return ms
+	}))
+
+	stream.Reset()
+	var buf bytes.Buffer
+	slog.New(slogx.NewRemoteHandler(&stream, nil)).With(slog.Attr{}, slog.Group("empty")).
+		Info("msg", slog.Attr{}, "a", 1, slog.Group("g", slog.Attr{}))
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &stream, slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+	t.Equal(buf.String(), "level=INFO msg=msg a=1\n")
+}
@@ -1,6 +1,11 @@
 package slogx
 
-import "log/slog"
+import (
+	"log/slog"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
 
 func ChainReplaceAttr(fs ...func([]string, slog.Attr) slog.Attr) func([]string, slog.Attr) slog.Attr {
 	if len(fs) == 0 {
@@ -17,3 +22,154 @@ func ChainReplaceAttr(fs ...func([]string, slog.Attr) slog.Attr) func([]string,
 		return a
 	}
 }
+
+// TrimSourcePrefix returns ReplaceAttr function which removes prefix
+// (e.g. module root directory) from a file path of source attr.
+func TrimSourcePrefix(prefix string) func([]string, slog.Attr) slog.Attr {
+	return replaceSourceFile(func(file string) string {
+		return strings.TrimPrefix(file, prefix)
+	})
+}
+
+// ShortSource returns ReplaceAttr function which keeps only n trailing
+// elements of a file path of source attr, e.g. n=2 results in "pkg/file.go".
+func ShortSource(n int) func([]string, slog.Attr) slog.Attr {
+	return replaceSourceFile(func(file string) string {
+		return lastPathElems(file, n)
+	})
+}
+
+// FuncSource returns ReplaceAttr function which replaces source attr
+// with a name of the calling function with removed prefix (e.g. module
+// path with trailing slash), like "pkg.(*T).Method". If withFile is true
+// then it is followed by file path and line, like "pkg.F file.go:42".
+// Use it after TrimSourcePrefix or ShortSource to also shorten file path.
+func FuncSource(prefix string, withFile bool) func([]string, slog.Attr) slog.Attr {
+	return func(g []string, a slog.Attr) slog.Attr {
+		source := sourceOf(g, a)
+		if source == nil || source.Function == "" {
+			return a
+		}
+		s := strings.TrimPrefix(source.Function, prefix)
+		if withFile {
+			s += " " + source.File + ":" + strconv.Itoa(source.Line)
+		}
+		return slog.String(a.Key, s)
+	}
+}
+
+// ModuleSource returns ReplaceAttr function which replaces file paths
+// belonging to main module of the binary with paths relative to module
+// root, e.g. "pkg/file.go". It changes source attr and file paths inside
+// stack attr returned by Stack.
+//
+// Module path is taken from build info, so it works without configuration
+// and regardless of the directory where binary was built or -trimpath.
+// Paths of other modules are not changed.
+func ModuleSource() func([]string, slog.Attr) slog.Attr {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path == "" {
+		return func(_ []string, a slog.Attr) slog.Attr { return a }
+	}
+	mainPkg := strings.TrimSuffix(info.Path, ".test")
+	return func(g []string, a slog.Attr) slog.Attr {
+		if a.Key == KeyStack && a.Value.Kind() == slog.KindString {
+			return slog.String(a.Key, moduleStack(info.Main.Path, mainPkg, a.Value.String()))
+		}
+		source := sourceOf(g, a)
+		if source == nil {
+			return a
+		}
+		short := *source
+		short.File = moduleFile(info.Main.Path, mainPkg, source.Function, source.File)
+		return slog.Any(a.Key, &short)
+	}
+}
+
+// moduleStack changes file paths in stack trace formatted as panic output.
+// Each file path follows a line with a function which contains it.
+func moduleStack(module, mainPkg, stack string) string {
+	lines := strings.Split(stack, "\n")
+	function := ""
+	for i, line := range lines {
+		file, ok := strings.CutPrefix(line, "\t")
+		if !ok {
+			function = strings.TrimPrefix(line, "created by ")
+			function, _, _ = strings.Cut(function, " in goroutine ")
+			if j := strings.LastIndexByte(function, '('); j > 0 {
+				function = function[:j]
+			}
+			continue
+		}
+		j := strings.LastIndexByte(file, ':')
+		if j < 0 {
+			continue
+		}
+		lines[i] = "\t" + moduleFile(module, mainPkg, function, file[:j]) + file[j:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// moduleFile returns file relative to module root if function belongs
+// to module or file unchanged otherwise.
+func moduleFile(module, mainPkg, function, file string) string {
+	pkg := funcPackage(function)
+	if pkg == "main" {
+		pkg = mainPkg
+	}
+	pkg = strings.TrimSuffix(pkg, "_test")
+	rel, ok := strings.CutPrefix(pkg, module)
+	switch {
+	case !ok:
+		return file
+	case rel == "":
+		return lastPathElems(file, 1)
+	case rel[0] == '/':
+		return rel[1:] + "/" + lastPathElems(file, 1)
+	default: // Other module with same prefix.
+		return file
+	}
+}
+
+// funcPackage returns package path of fully-qualified function name,
+// e.g. "example.com/mod/pkg" for "example.com/mod/pkg.(*T).Method".
+func funcPackage(function string) string {
+	i := strings.LastIndexByte(function, '/')
+	pkg, _, _ := strings.Cut(function[i+1:], ".")
+	return function[:i+1] + pkg
+}
+
+func lastPathElems(path string, n int) string {
+	if n <= 0 {
+		return path
+	}
+	i := len(path)
+	for range n {
+		i = strings.LastIndexByte(path[:i], '/')
+		if i < 0 {
+			return path
+		}
+	}
+	return path[i+1:]
+}
+
+func replaceSourceFile(f func(string) string) func([]string, slog.Attr) slog.Attr {
+	return func(g []string, a slog.Attr) slog.Attr {
+		source := sourceOf(g, a)
+		if source == nil {
+			return a
+		}
+		short := *source
+		short.File = f(source.File)
+		return slog.Any(a.Key, &short)
+	}
+}
+
+// sourceOf returns nil if a is not a top-level source attr.
+func sourceOf(g []string, a slog.Attr) *slog.Source {
+	if len(g) != 0 || a.Key != slog.SourceKey {
+		return nil
+	}
+	source, _ := a.Value.Any().(*slog.Source)
+	return source
+}
@@ -1,6 +1,7 @@
 package slogx_test
 
 import (
+	"bytes"
 	"log/slog"
 	"testing"
 	"time"
@@ -56,3 +57,113 @@ func TestReplaceAttr(tt *testing.T) {
 	t.DeepEqual(fn([]string{"g"}, slog.Attr{Key: id, Value: slog.IntValue(325)}), slog.Attr{Key: userID, Value: slog.StringValue("REDACTED")})
 	t.DeepEqual(fn([]string{}, slog.Attr{Key: slog.TimeKey, Value: slog.AnyValue(time.Now())}), slog.Attr{})
 }
+
+func TestSourceReplaceAttr(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	source := func(file string) slog.Attr {
+		return slog.Any(slog.SourceKey, &slog.Source{Function: "pkg.F", File: file, Line: 42})
+	}
+	tests := []struct {
+		f    func([]string, slog.Attr) slog.Attr
+		file string
+		want string
+	}{
+		{slogx.TrimSourcePrefix("/src/mod/"), "/src/mod/pkg/file.go", "pkg/file.go"},
+		{slogx.TrimSourcePrefix("/src/mod/"), "/other/pkg/file.go", "/other/pkg/file.go"},
+		{slogx.ShortSource(0), "/src/mod/pkg/file.go", "/src/mod/pkg/file.go"},
+		{slogx.ShortSource(1), "/src/mod/pkg/file.go", "file.go"},
+		{slogx.ShortSource(2), "/src/mod/pkg/file.go", "pkg/file.go"},
+		{slogx.ShortSource(4), "/src/mod/pkg/file.go", "src/mod/pkg/file.go"},
+		{slogx.ShortSource(5), "/src/mod/pkg/file.go", "/src/mod/pkg/file.go"},
+		{slogx.ShortSource(2), "file.go", "file.go"},
+	}
+	for _, tc := range tests {
+		t.Run("", func(tt *testing.T) {
+			t := check.T(tt)
+			t.Equal(tc.f(nil, source(tc.file)).String(), source(tc.want).String())
+		})
+	}
+
+	f := slogx.ShortSource(1)
+	t.Equal(f([]string{"g"}, source("/a/b.go")).String(), source("/a/b.go").String())
+	t.Equal(f(nil, slog.String(slog.SourceKey, "/a/b.go")).String(), slog.String(slog.SourceKey, "/a/b.go").String())
+	t.Equal(f(nil, slog.String("file", "/a/b.go")).String(), slog.String("file", "/a/b.go").String())
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{AddSource: true, ReplaceAttr: f}))
+	log.Info("msg")
+	t.Match(buf.String(), ` source=replace_attr_test.go:\d+ msg=msg`)
+}
+
+func TestFuncSource(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	source := slog.Any(slog.SourceKey, &slog.Source{Function: "example.com/mod/pkg.(*T).F", File: "/src/mod/pkg/file.go", Line: 42})
+	tests := []struct {
+		f    func([]string, slog.Attr) slog.Attr
+		want string
+	}{
+		{slogx.FuncSource("", false), "example.com/mod/pkg.(*T).F"},
+		{slogx.FuncSource("example.com/mod/", false), "pkg.(*T).F"},
+		{slogx.FuncSource("example.org/", false), "example.com/mod/pkg.(*T).F"},
+		{slogx.FuncSource("example.com/mod/", true), "pkg.(*T).F /src/mod/pkg/file.go:42"},
+		{slogx.ChainReplaceAttr(slogx.ShortSource(1), slogx.FuncSource("example.com/mod/", true)), "pkg.(*T).F file.go:42"},
+	}
+	for _, tc := range tests {
+		t.Run("", func(tt *testing.T) {
+			t := check.T(tt)
+			t.Equal(tc.f(nil, source).String(), slog.String(slog.SourceKey, tc.want).String())
+		})
+	}
+
+	f := slogx.FuncSource("", true)
+	t.Equal(f([]string{"g"}, source).String(), source.String())
+	noFunc := slog.Any(slog.SourceKey, &slog.Source{File: "/a/b.go", Line: 1})
+	t.Equal(f(nil, noFunc).String(), noFunc.String())
+	t.Equal(f(nil, slog.String(slog.SourceKey, "/a/b.go")).String(), slog.String(slog.SourceKey, "/a/b.go").String())
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{AddSource: true, ReplaceAttr: slogx.FuncSource("github.com/powerman/", false)}))
+	log.Info("msg")
+	t.Match(buf.String(), ` source=slogx_test.TestFuncSource msg=msg`)
+}
+
+func TestModuleSource(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	f := slogx.ModuleSource()
+	source := func(function, file string) slog.Attr {
+		return slog.Any(slog.SourceKey, &slog.Source{Function: function, File: file, Line: 42})
+	}
+	tests := []struct {
+		function string
+		file     string
+		want     string
+	}{
+		{"github.com/powerman/slogx.F", "/src/slogx/file.go", "file.go"},
+		{"github.com/powerman/slogx_test.F", "/src/slogx/file_test.go", "file_test.go"},
+		{"github.com/powerman/slogx/pkg/sub.(*T).F", "/src/slogx/pkg/sub/file.go", "pkg/sub/file.go"},
+		{"github.com/powerman/slogx/pkg.F.func1", "github.com/powerman/slogx/pkg/file.go", "pkg/file.go"},
+		{"github.com/powerman/slogxtra.F", "/src/slogxtra/file.go", "/src/slogxtra/file.go"},
+		{"github.com/powerman/check.F", "/go/pkg/mod/check/file.go", "/go/pkg/mod/check/file.go"},
+		{"main.main", "/src/slogx/file.go", "file.go"},
+	}
+	for _, tc := range tests {
+		t.Run("", func(tt *testing.T) {
+			t := check.T(tt)
+			t.Equal(f(nil, source(tc.function, tc.file)).String(), source(tc.function, tc.want).String())
+		})
+	}
+	t.Equal(f([]string{"g"}, source("main.main", "/a/b.go")).String(), source("main.main", "/a/b.go").String())
+	t.Equal(f(nil, slog.String(slog.SourceKey, "/a/b.go")).String(), slog.String(slog.SourceKey, "/a/b.go").String())
+
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{AddSource: true, ReplaceAttr: f}))
+	log.Info("msg", slogx.Stack())
+	t.Match(buf.String(), ` source=replace_attr_test.go:\d+ msg=msg stack="goroutine \d+ \[running\]:\\ngithub.com/powerman/slogx_test.TestModuleSource\(.*\)\\n\\treplace_attr_test.go:\d+ \+0x[0-9a-f]+\\n`)
+	t.Match(buf.String(), `\\ncreated by testing.\(\*T\).Run in goroutine \d+\\n\\t/\S+/testing.go:\d+ \+0x[0-9a-f]+"\n$`)
+}
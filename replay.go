@@ -0,0 +1,39 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Replay feeds records into handler, e.g. to reproduce captured production
+// logs locally using development-friendly handler.
+//
+// If speed > 0 then Replay keeps original time intervals between records
+// divided by speed (speed=1 replays in real time, speed=2 is twice faster).
+// Otherwise records are fed without delays. Records with zero Time
+// are fed without delays.
+//
+// Records not enabled by handler are skipped.
+// Replay stops and returns error returned by handler's Handle.
+func Replay(records []slog.Record, handler slog.Handler, speed float64) error {
+	ctx := context.Background()
+	var start, first time.Time
+	for i := range records {
+		if speed > 0 && !records[i].Time.IsZero() {
+			if first.IsZero() {
+				start, first = time.Now(), records[i].Time
+			}
+			offset := time.Duration(float64(records[i].Time.Sub(first)) / speed)
+			time.Sleep(time.Until(start.Add(offset)))
+		}
+		if !handler.Enabled(ctx, records[i].Level) {
+			continue
+		}
+		err := handler.Handle(ctx, records[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+	"go.uber.org/mock/gomock"
+
+	"github.com/powerman/slogx"
+)
+
+func TestReplay(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	now := time.Now()
+	records := []slog.Record{
+		slog.NewRecord(now, slog.LevelInfo, "first", 0),
+		slog.NewRecord(now.Add(time.Second), slog.LevelDebug, "second", 0),
+		slog.NewRecord(time.Time{}, slog.LevelWarn, "third", 0),
+		slog.NewRecord(now.Add(2*time.Second), slog.LevelError, "fourth", 0),
+	}
+
+	var buf bytes.Buffer
+	h := slog.NewTextHandler(&buf, nil)
+	t.Nil(slogx.Replay(records, h, 0))
+	t.Match(buf.String(), `(?s)^[^\n]*msg=first\n[^\n]*msg=third\n[^\n]*msg=fourth\n$`)
+
+	buf.Reset()
+	start := time.Now()
+	t.Nil(slogx.Replay(records, h, 20))
+	t.GE(time.Since(start), 100*time.Millisecond)
+	t.Match(buf.String(), `(?s)^[^\n]*msg=first\n[^\n]*msg=third\n[^\n]*msg=fourth\n$`)
+}
+
+func TestReplayErr(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	records := []slog.Record{
+		slog.NewRecord(time.Now(), slog.LevelInfo, "first", 0),
+		slog.NewRecord(time.Now(), slog.LevelInfo, "second", 0),
+	}
+
+	h := NewMockHandler(ctrl)
+	h.EXPECT().Enabled(context.Background(), slog.LevelInfo).Return(true)
+	h.EXPECT().Handle(context.Background(), gomock.Any()).Return(io.EOF)
+	t.Err(slogx.Replay(records, h, 0), io.EOF)
+}
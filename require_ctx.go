@@ -0,0 +1,73 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const msgRequireContext = "logging without context"
+
+// RequireContextHandler reports non-Context logging calls
+// (like slog.Info instead of slog.InfoContext), which bypass attrs stored
+// in request's context. It is an operational complement to linter
+// (see README) for catching such calls made by code you can't lint.
+//
+// It detects Handle calls with ctx which has no handler stored by
+// NewContextWithHandler and, once per call site, logs WARN record with
+// message "logging without context" and "caller" attr with file:line of
+// the call site. Records are passed to wrapped handler as is.
+//
+// It should be used as a fallback handler of CtxHandler,
+// usually with LaxCtxHandler option.
+type RequireContextHandler struct {
+	wrapHandler
+	root slog.Handler
+	now  func() time.Time
+	seen *sync.Map
+}
+
+// RequireContext creates a RequireContextHandler which wraps handler.
+func RequireContext(handler slog.Handler, opts ...handlerOption) *RequireContextHandler {
+	return &RequireContextHandler{
+		wrapHandler: wrapHandler{next: handler},
+		root:        handler,
+		now:         newHandlerOptions(opts).now,
+		seen:        &sync.Map{},
+	}
+}
+
+// Handle implements slog.Handler interface.
+func (h *RequireContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if HandlerFromContext(ctx) == nil && r.PC != 0 {
+		if _, seen := h.seen.LoadOrStore(r.PC, struct{}{}); !seen {
+			h.report(ctx, r.PC)
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *RequireContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return withAttrs(h, attrs)
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *RequireContextHandler) WithGroup(name string) slog.Handler {
+	return withGroup(h, name)
+}
+
+// report uses handler without attrs and groups added by WithAttrs and
+// WithGroup because they are not related to the report.
+func (h *RequireContextHandler) report(ctx context.Context, pc uintptr) {
+	if !h.root.Enabled(ctx, slog.LevelWarn) {
+		return
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	rec := slog.NewRecord(h.now(), slog.LevelWarn, msgRequireContext, pc)
+	rec.AddAttrs(slog.String("caller", frame.File+":"+strconv.Itoa(frame.Line)))
+	_ = h.root.Handle(ctx, rec)
+}
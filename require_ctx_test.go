@@ -0,0 +1,40 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestRequireContext(tt *testing.T) {
+	t := check.T(tt)
+
+	var buf bytes.Buffer
+	next := slog.NewTextHandler(&buf, nil)
+	h := slogx.RequireContext(next, slogx.ClockOption(newFakeClock().Now))
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+	t.False(h.Enabled(context.Background(), slog.LevelDebug))
+
+	ctx := slogx.SetDefaultCtxHandler(context.Background(), h, slogx.LaxCtxHandler())
+	slog.InfoContext(ctx, "with ctx")
+	t.Match(buf.String(), `^time=\S+ level=INFO msg="with ctx"\n$`)
+
+	buf.Reset()
+	for range 2 {
+		slog.Info("without ctx") //nolint:forbidigo // By design.
+	}
+	t.Match(buf.String(), `^time=2026-01-02T03:04:05.000Z level=WARN msg="logging without context" caller=\S*/slogx/require_ctx_test.go:30\n`+
+		`time=\S+ level=INFO msg="without ctx"\n`+
+		`time=\S+ level=INFO msg="without ctx"\n$`)
+
+	buf.Reset()
+	slog.With("a", 1).WithGroup("g").Info("without ctx") //nolint:forbidigo // By design.
+	t.Match(buf.String(), `^time=\S+ level=WARN msg="logging without context" caller=\S*/slogx/require_ctx_test.go:37\n`+
+		`time=\S+ level=INFO msg="without ctx" a=1\n$`)
+}
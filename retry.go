@@ -0,0 +1,85 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryMaxBackoff is a default value of RetryOptions.MaxBackoff.
+const RetryMaxBackoff = 30 * time.Second
+
+// RetryOptions configures RetryHandler.
+type RetryOptions struct {
+	// Max is a maximum amount of retries after first failed Handle call.
+	Max int
+	// Backoff is a delay before first retry. It doubles on each
+	// next retry. Actual delay is randomized between Backoff/2 and Backoff
+	// to avoid retrying in sync with other handlers.
+	// Negative value is same as zero.
+	Backoff time.Duration
+	// MaxBackoff limits growth of Backoff.
+	// If zero or negative then RetryMaxBackoff is used.
+	MaxBackoff time.Duration
+	// RetryIf reports is error returned by Handle transient and should be
+	// retried. If nil then all errors are retried.
+	RetryIf func(error) bool
+}
+
+// RetryHandler retries failed Handle calls of next handler
+// (e.g. handler which sends records over network) using exponential backoff.
+// It stops retrying when ctx passed to Handle is done.
+//
+// Handle blocks the caller (i.e. the code which logs a record) for
+// the whole retry sequence, so keep Max and MaxBackoff small or use ctx
+// with a deadline.
+type RetryHandler struct {
+	wrapHandler
+	opts RetryOptions
+}
+
+// NewRetryHandler creates a RetryHandler which wraps next handler.
+func NewRetryHandler(next slog.Handler, opts RetryOptions) *RetryHandler {
+	opts.Backoff = max(0, opts.Backoff)
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = RetryMaxBackoff
+	}
+	opts.Backoff = min(opts.Backoff, opts.MaxBackoff)
+	return &RetryHandler{
+		wrapHandler: wrapHandler{next: next},
+		opts:        opts,
+	}
+}
+
+// Handle implements slog.Handler interface.
+// It returns last error returned by next handler.
+func (h *RetryHandler) Handle(ctx context.Context, r slog.Record) error {
+	backoff := h.opts.Backoff
+	for retry := 0; ; retry++ {
+		err := h.next.Handle(ctx, r)
+		if err == nil || retry >= h.opts.Max || h.opts.RetryIf != nil && !h.opts.RetryIf(err) {
+			return err
+		}
+
+		delay := backoff/2 + rand.N(backoff/2+1) //nolint:gosec // Jitter does not need crypto/rand.
+		backoff = min(backoff*2, h.opts.MaxBackoff)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *RetryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return withAttrs(h, attrs)
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *RetryHandler) WithGroup(name string) slog.Handler {
+	return withGroup(h, name)
+}
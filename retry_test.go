@@ -0,0 +1,91 @@
+package slogx_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+	"go.uber.org/mock/gomock"
+
+	"github.com/powerman/slogx"
+)
+
+func TestRetryHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	next := NewMockHandler(ctrl)
+	h := slogx.NewRetryHandler(next, slogx.RetryOptions{
+		Max:     3,
+		Backoff: 10 * time.Millisecond,
+		RetryIf: func(err error) bool { return !errors.Is(err, io.ErrClosedPipe) },
+	})
+
+	next.EXPECT().Enabled(ctx, slog.LevelDebug).Return(false)
+	t.False(h.Enabled(ctx, slog.LevelDebug))
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+
+	next.EXPECT().Handle(ctx, r).Return(nil)
+	t.Nil(h.Handle(ctx, r))
+
+	next.EXPECT().Handle(ctx, r).Return(io.ErrClosedPipe)
+	t.Err(h.Handle(ctx, r), io.ErrClosedPipe)
+
+	next.EXPECT().Handle(ctx, r).Return(io.EOF).Times(2)
+	next.EXPECT().Handle(ctx, r).Return(nil)
+	start := time.Now()
+	t.Nil(h.Handle(ctx, r))
+	t.GE(time.Since(start), 15*time.Millisecond)
+
+	next.EXPECT().Handle(ctx, r).Return(io.EOF).Times(4)
+	start = time.Now()
+	t.Err(h.Handle(ctx, r), io.EOF)
+	t.GE(time.Since(start), 35*time.Millisecond)
+
+	ctxCancel, cancel := context.WithCancel(ctx)
+	cancel()
+	next.EXPECT().Handle(ctxCancel, r).Return(io.EOF)
+	t.Err(h.Handle(ctxCancel, r), io.EOF)
+
+	next2 := NewMockHandler(ctrl)
+	next.EXPECT().WithAttrs([]slog.Attr{slog.Int("a", 1)}).Return(next2)
+	next2.EXPECT().WithGroup("g").Return(next2)
+	next2.EXPECT().Handle(ctx, r).Return(io.EOF)
+	next2.EXPECT().Handle(ctx, r).Return(nil)
+	t.Nil(h.WithAttrs([]slog.Attr{slog.Int("a", 1)}).WithGroup("g").Handle(ctx, r))
+}
+
+func TestRetryHandlerBackoff(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, "msg", 0)
+	next := NewMockHandler(ctrl)
+
+	h := slogx.NewRetryHandler(next, slogx.RetryOptions{Max: 2, Backoff: -time.Second})
+	next.EXPECT().Handle(ctx, r).Return(io.EOF).Times(3)
+	t.Err(h.Handle(ctx, r), io.EOF)
+
+	// Without MaxBackoff first retry would wait until ctx is done.
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	h = slogx.NewRetryHandler(next, slogx.RetryOptions{
+		Max:        4,
+		Backoff:    time.Hour,
+		MaxBackoff: 10 * time.Millisecond,
+	})
+	next.EXPECT().Handle(ctx, r).Return(io.EOF).Times(5)
+	start := time.Now()
+	t.Err(h.Handle(ctx, r), io.EOF)
+	t.GE(time.Since(start), 20*time.Millisecond)
+}
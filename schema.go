@@ -0,0 +1,110 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+)
+
+const unknownKeyPrefix = "x_"
+
+// Schema declares allowed attr keys and kinds of their values.
+// Keys inside groups must be qualified by group names separated by dot,
+// e.g. "req.id". Kind slog.KindAny allows value of any kind.
+type Schema map[string]slog.Kind
+
+// SchemaHandler enforces Schema on attrs of records and attrs added
+// using WithAttrs before passing them to next handler.
+//
+// Attrs with unknown keys or with kind of value not allowed by Schema
+// have "x_" prefix added to their key, or are dropped if SchemaHandler
+// was created with DropSchemaHandler option. Group attrs are checked
+// recursively.
+type SchemaHandler struct {
+	wrapHandler
+	schema Schema
+	drop   bool
+	prefix string
+}
+
+type schemaHandlerOption func(*SchemaHandler)
+
+// NewSchemaHandler creates a SchemaHandler which checks attrs using schema
+// and passes them to next handler.
+func NewSchemaHandler(next slog.Handler, schema Schema, opts ...schemaHandlerOption) *SchemaHandler {
+	h := &SchemaHandler{
+		wrapHandler: wrapHandler{next: next},
+		schema:      schema,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// DropSchemaHandler is an option for dropping attrs not allowed by Schema
+// instead of adding "x_" prefix to their keys.
+func DropSchemaHandler() schemaHandlerOption { //nolint:revive // By design.
+	return func(h *SchemaHandler) {
+		h.drop = true
+	}
+}
+
+// Handle implements slog.Handler interface.
+func (h *SchemaHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := getAttrs(r.NumAttrs())
+	defer putAttrs(attrs)
+	*attrs = appendRecordAttrs(*attrs, r)
+	checked := getAttrs(len(*attrs))
+	defer putAttrs(checked)
+	*checked = h.appendCheckedAttrs(*checked, h.prefix, *attrs)
+
+	r2 := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r2.AddAttrs(*checked...)
+	return h.next.Handle(ctx, r2)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *SchemaHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithAttrs(h.appendCheckedAttrs(nil, h.prefix, attrs))
+	return &h2
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *SchemaHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+func (h *SchemaHandler) appendCheckedAttrs(res []slog.Attr, prefix string, attrs []slog.Attr) []slog.Attr {
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		switch {
+		case a.Equal(slog.Attr{}):
+		case a.Value.Kind() == slog.KindGroup && a.Key == "":
+			res = h.appendCheckedAttrs(res, prefix, a.Value.Group())
+		case a.Value.Kind() == slog.KindGroup:
+			group := h.appendCheckedAttrs(nil, prefix+a.Key+".", a.Value.Group())
+			res = append(res, slog.Attr{Key: a.Key, Value: slog.GroupValue(group...)})
+		case h.allowed(prefix+a.Key, a.Value.Kind()):
+			res = append(res, a)
+		case !h.drop:
+			a.Key = unknownKeyPrefix + a.Key
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+func (h *SchemaHandler) allowed(key string, kind slog.Kind) bool {
+	allowedKind, ok := h.schema[key]
+	return ok && (allowedKind == slog.KindAny || allowedKind == kind)
+}
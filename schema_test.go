@@ -0,0 +1,52 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestSchemaHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	schema := slogx.Schema{
+		"app":       slog.KindString,
+		"req.id":    slog.KindInt64,
+		"req.extra": slog.KindAny,
+		"resp.code": slog.KindInt64,
+	}
+	var buf bytes.Buffer
+	next := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: dropTime,
+	})
+
+	h := slogx.NewSchemaHandler(next, schema)
+	t.True(h.Enabled(context.Background(), slog.LevelInfo))
+	t.False(h.Enabled(context.Background(), slog.LevelDebug))
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+
+	log := slog.New(h).With("app", "test", "user", "bob")
+	log.Info("msg", "app", 42, slog.Group("resp", "code", 200, "size", 10), slog.Group("", "req", "inline"))
+	t.Equal(buf.String(), "level=INFO msg=msg app=test x_user=bob x_app=42 resp.code=200 resp.x_size=10 x_req=inline\n")
+
+	buf.Reset()
+	log.WithGroup("req").With("id", 1).Info("msg", "extra", true, "id", "bad")
+	t.Equal(buf.String(), "level=INFO msg=msg app=test x_user=bob req.id=1 req.extra=true req.x_id=bad\n")
+
+	buf.Reset()
+	log = slog.New(slogx.NewSchemaHandler(next, schema, slogx.DropSchemaHandler())).With("app", "test", "user", "bob")
+	log.WithGroup("req").Info("msg", "id", 1, "size", 10, slog.Group("resp", "code", 200))
+	t.Equal(buf.String(), "level=INFO msg=msg app=test req.id=1\n")
+
+	buf.Reset()
+	log = slog.New(slogx.NewSchemaHandler(next, schema)).With(slog.Attr{})
+	log.Info("msg", slog.Attr{}, "app", "test", slog.Group("req", slog.Attr{}, "id", 1))
+	t.Equal(buf.String(), "level=INFO msg=msg app=test req.id=1\n")
+}
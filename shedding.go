@@ -0,0 +1,99 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// KeyDropped is a key of attr added by SheddingHandler.
+const KeyDropped = "dropped"
+
+// SheddingHandler keeps application responsive when next handler's
+// writer blocks (slow disk, blocked pipe) by dropping records below
+// WARN level for a while.
+//
+// When next handler's Handle takes longer than threshold, SheddingHandler
+// drops records below WARN level during cooldown. Records at WARN level
+// and above are always passed to next handler synchronously.
+// First record passed to next handler (or any handler derived from it by
+// WithAttrs and WithGroup) after some records were dropped gets "dropped"
+// attr with amount of dropped records. This attr is added outside of
+// groups added by WithGroup.
+type SheddingHandler struct {
+	wrapHandler
+	outside   outsideGroups
+	threshold time.Duration
+	cooldown  time.Duration
+	now       func() time.Time
+	state     *sheddingState
+}
+
+type sheddingState struct {
+	shedUntil atomic.Int64
+	dropped   atomic.Int64
+	total     atomic.Int64
+}
+
+// NewSheddingHandler creates a SheddingHandler which wraps next handler.
+func NewSheddingHandler(next slog.Handler, threshold, cooldown time.Duration, opts ...handlerOption) *SheddingHandler {
+	return &SheddingHandler{
+		wrapHandler: wrapHandler{next: next},
+		outside:     outsideGroups{root: next},
+		threshold:   threshold,
+		cooldown:    cooldown,
+		now:         newHandlerOptions(opts).now,
+		state:       &sheddingState{},
+	}
+}
+
+// Handle implements slog.Handler interface.
+func (h *SheddingHandler) Handle(ctx context.Context, r slog.Record) error {
+	start := h.now()
+	if r.Level < slog.LevelWarn && start.UnixNano() < h.state.shedUntil.Load() {
+		h.state.dropped.Add(1)
+		h.state.total.Add(1)
+		return nil
+	}
+
+	next := h.next
+	if dropped := h.state.dropped.Swap(0); dropped > 0 && h.outside.grouped() {
+		next = h.outside.handler(slog.Int64(KeyDropped, dropped))
+	} else if dropped > 0 {
+		r = r.Clone()
+		r.AddAttrs(slog.Int64(KeyDropped, dropped))
+	}
+	err := next.Handle(ctx, r)
+	if end := h.now(); end.Sub(start) > h.threshold {
+		h.state.shedUntil.Store(end.Add(h.cooldown).UnixNano())
+	}
+	return err
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *SheddingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.outside = h.outside.withAttrs(h2.next, attrs)
+	return &h2
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *SheddingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.outside = h.outside.withGroup(name)
+	return &h2
+}
+
+// Dropped returns total amount of dropped records.
+func (h *SheddingHandler) Dropped() int64 {
+	return h.state.total.Load()
+}
@@ -0,0 +1,61 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+type slowWriter struct {
+	bytes.Buffer
+	clock *fakeClock
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	w.clock.Add(w.delay)
+	return w.Buffer.Write(p)
+}
+
+func TestSheddingHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	clock := newFakeClock()
+	w := &slowWriter{clock: clock}
+	next := slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       slog.LevelDebug,
+		ReplaceAttr: dropTime,
+	})
+	h := slogx.NewSheddingHandler(next, 10*time.Millisecond, 50*time.Millisecond, slogx.ClockOption(clock.Now))
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+	t.True(h.Enabled(context.Background(), slog.LevelDebug))
+
+	log := slog.New(h)
+	log.Debug("first")
+	w.delay = 20 * time.Millisecond
+	log.Info("second")
+	w.delay = 0
+	log.Info("dropped")
+	log.With("a", 1).WithGroup("g").Debug("dropped")
+	log.Warn("third")
+	log.Info("dropped")
+	t.Equal(h.Dropped(), int64(3))
+	clock.Add(50 * time.Millisecond)
+	log.With("a", 1).WithGroup("g").With("b", 2).Info("fourth", "c", 3)
+	log.Info("fifth")
+	t.Equal(w.String(), `level=DEBUG msg=first
+level=INFO msg=second
+level=WARN msg=third dropped=2
+level=INFO msg=fourth a=1 dropped=1 g.b=2 g.c=3
+level=INFO msg=fifth
+`)
+	t.Equal(h.Dropped(), int64(3))
+}
@@ -0,0 +1,107 @@
+package slogxtest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// EnvArtifactsDir is a name of environment variable with a directory
+// used by NewArtifactHandler to store artifact files.
+const EnvArtifactsDir = "SLOGXTEST_ARTIFACTS_DIR"
+
+// NewArtifactHandler creates a slog.JSONHandler which writes records
+// into per-test artifact file named after package of the caller and
+// tb.Name() with ".jsonl" suffix, using the given options.
+// Existing files are never overwritten: if file already exists
+// (e.g. NewArtifactHandler was called twice in same test) then
+// a number is added before suffix, e.g. ".2.jsonl".
+//
+// File is created in a directory given by environment variable
+// SLOGXTEST_ARTIFACTS_DIR (e.g. collected by CI) or in a new temporary
+// directory. File is closed when test and all its subtests complete,
+// and if test has failed then path to the file is logged. Temporary
+// directory is removed only if test has not failed, so logged path
+// remains valid.
+func NewArtifactHandler(tb testing.TB, opts *slog.HandlerOptions) *slog.JSONHandler {
+	tb.Helper()
+
+	pkg := "unknown"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		pkg = funcPackage(runtime.FuncForPC(pc).Name())
+	}
+
+	dir := os.Getenv(EnvArtifactsDir)
+	isTemp := dir == ""
+	var err error
+	if isTemp {
+		dir, err = os.MkdirTemp("", "slogxtest-")
+	} else {
+		err = os.MkdirAll(dir, 0o750)
+	}
+	if err != nil {
+		tb.Fatalf("failed to create artifacts dir: %s", err)
+	}
+
+	f, err := createArtifact(dir, artifactName(pkg+"."+tb.Name()))
+	if err != nil {
+		tb.Fatalf("failed to create artifact file: %s", err)
+	}
+	path := f.Name()
+	tb.Cleanup(func() {
+		err := f.Close()
+		if err != nil {
+			tb.Errorf("failed to close artifact file: %s", err)
+		}
+		switch {
+		case tb.Failed():
+			tb.Logf("log records are written to %s", path)
+		case isTemp:
+			err = os.RemoveAll(dir)
+			if err != nil {
+				tb.Errorf("failed to remove artifacts dir: %s", err)
+			}
+		}
+	})
+
+	return slog.NewJSONHandler(f, opts)
+}
+
+func createArtifact(dir, name string) (*os.File, error) {
+	path := filepath.Join(dir, name+".jsonl")
+	for i := 2; ; i++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:gosec // False positive.
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s.%d.jsonl", name, i))
+	}
+}
+
+// funcPackage returns package path of function name returned by
+// runtime.Func.Name, e.g. "example.com/pkg_test" for
+// "example.com/pkg_test.TestName.func1".
+func funcPackage(name string) string {
+	slash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[slash+1:], '.'); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
+
+func artifactName(testName string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, testName)
+}
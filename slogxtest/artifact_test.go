@@ -0,0 +1,55 @@
+package slogxtest_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx/slogxtest"
+)
+
+func TestNewArtifactHandler(tt *testing.T) {
+	t := check.T(tt)
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	t.Setenv(slogxtest.EnvArtifactsDir, dir)
+
+	t.Run("sub test/1", func(tt *testing.T) {
+		t := check.T(tt)
+		log := slog.New(slogxtest.NewArtifactHandler(t, nil))
+		log2 := slog.New(slogxtest.NewArtifactHandler(t, nil))
+		log.Info("first", "a", 1)
+		log2.Info("other")
+		log.Warn("second")
+	})
+
+	const name = "github.com_powerman_slogx_slogxtest_test.TestNewArtifactHandler_sub_test_1"
+	buf, err := os.ReadFile(filepath.Join(dir, name+".jsonl"))
+	t.Nil(err)
+	t.Match(string(buf), `^{"time":"\S+","level":"INFO","msg":"first","a":1}\n{"time":"\S+","level":"WARN","msg":"second"}\n$`)
+	buf, err = os.ReadFile(filepath.Join(dir, name+".2.jsonl"))
+	t.Nil(err)
+	t.Match(string(buf), `^{"time":"\S+","level":"INFO","msg":"other"}\n$`)
+
+	t.Setenv(slogxtest.EnvArtifactsDir, "")
+	tb := &fakeTB{name: "TestPassed"}
+	slog.New(slogxtest.NewArtifactHandler(tb, nil)).Info("msg")
+	tb.done()
+	t.Len(tb.logs, 0)
+	t.Len(tb.errors, 0)
+
+	tb = &fakeTB{name: "TestFailed", failed: true}
+	slog.New(slogxtest.NewArtifactHandler(tb, nil)).Info("msg")
+	tb.done()
+	t.Len(tb.errors, 0)
+	t.Len(tb.logs, 1)
+	path := strings.TrimPrefix(tb.logs[0], "log records are written to ")
+	t.Equal(filepath.Base(path), "github.com_powerman_slogx_slogxtest_test.TestFailed.jsonl")
+	defer os.RemoveAll(filepath.Dir(path))
+	buf, err = os.ReadFile(path)
+	t.Nil(err)
+	t.Match(string(buf), `^{"time":"\S+","level":"INFO","msg":"msg"}\n$`)
+}
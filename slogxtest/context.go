@@ -0,0 +1,69 @@
+package slogxtest
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// KeyTest is a key of attr added by TestNameHandler.
+const KeyTest = "test"
+
+type contextKey int
+
+const contextKeyTestName contextKey = iota
+
+// Context returns a new Context that carries tb's test name
+// to be added to records by TestNameHandler.
+func Context(tb testing.TB) context.Context {
+	return context.WithValue(context.Background(), contextKeyTestName, tb.Name())
+}
+
+// TestNameHandler adds attr with test name stored in ctx by Context
+// to records before passing them to next handler. This way records
+// written by parallel tests into shared sink may be attributed to the
+// right test. Like other record's attrs, it will be qualified by groups
+// added using WithGroup.
+type TestNameHandler struct {
+	next slog.Handler
+}
+
+// NewTestNameHandler creates a TestNameHandler which wraps next handler.
+func NewTestNameHandler(next slog.Handler) *TestNameHandler {
+	return &TestNameHandler{next: next}
+}
+
+// Enabled implements slog.Handler interface.
+func (h *TestNameHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.next.Enabled(ctx, l)
+}
+
+// Handle implements slog.Handler interface.
+func (h *TestNameHandler) Handle(ctx context.Context, r slog.Record) error {
+	if name, ok := ctx.Value(contextKeyTestName).(string); ok {
+		r = r.Clone()
+		r.AddAttrs(slog.String(KeyTest, name))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *TestNameHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return &TestNameHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *TestNameHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &TestNameHandler{next: h.next.WithGroup(name)}
+}
+
+// Unwrap returns next handler.
+func (h *TestNameHandler) Unwrap() slog.Handler {
+	return h.next
+}
@@ -0,0 +1,33 @@
+package slogxtest_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx/slogxtest"
+)
+
+func TestTestNameHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var buf bytes.Buffer
+	next := slog.NewTextHandler(&buf, nil)
+	h := slogxtest.NewTestNameHandler(next)
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+	t.False(h.Enabled(context.Background(), slog.LevelDebug))
+
+	log := slog.New(h).With("a", 1)
+	log.InfoContext(context.Background(), "msg")
+	t.Match(buf.String(), ` msg=msg a=1\n$`)
+
+	buf.Reset()
+	ctx := slogxtest.Context(t)
+	log.WithGroup("g").InfoContext(ctx, "msg", "b", 2)
+	t.Match(buf.String(), ` msg=msg a=1 g.b=2 g.test=TestTestNameHandler\n$`)
+}
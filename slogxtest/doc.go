@@ -0,0 +1,2 @@
+// Package slogxtest contains helpers for using log/slog in tests.
+package slogxtest
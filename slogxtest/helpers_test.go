@@ -0,0 +1,23 @@
+package slogxtest_test
+
+import (
+	"log/slog"
+
+	"github.com/powerman/check"
+)
+
+// dropTime is a ReplaceAttr func which removes time to make output stable.
+func dropTime(_ []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
+
+// checkWithNoop checks that WithAttrs and WithGroup return h itself
+// if there is nothing to add.
+func checkWithNoop(t *check.C, h slog.Handler) {
+	t.Helper()
+	t.DeepEqual(h.WithAttrs(nil), h)
+	t.DeepEqual(h.WithGroup(""), h)
+}
@@ -0,0 +1,122 @@
+package slogxtest
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TBHandlerOptions are options for a TBHandler.
+type TBHandlerOptions struct {
+	// HandlerOptions are used to format records using slog.TextHandler.
+	slog.HandlerOptions
+	// FailLevel makes test fail (using Errorf) on records at or
+	// above this level. If nil, records do not fail the test.
+	FailLevel slog.Leveler
+}
+
+// TBHandler outputs records formatted by slog.TextHandler using Log
+// method of testing.TB, so records are shown in test's output
+// (only for failed tests or with -v flag) and scoped to this test.
+//
+// File and line added by Log always point inside this package because
+// testing.TB.Helper can't skip log/slog frames, so each record is also
+// prefixed with file:line of the logging call (if record has PC).
+//
+// Records handled after test completes (e.g. by background goroutines)
+// are dropped instead of making Log panic. To be exact, they are dropped
+// after a cleanup function registered by NewTBHandler, so records logged
+// by cleanup functions registered before NewTBHandler are dropped too.
+type TBHandler struct {
+	text      slog.Handler
+	w         *tbWriter
+	failLevel slog.Leveler
+}
+
+// tbWriter must be used with mu locked.
+type tbWriter struct {
+	mu     sync.Mutex
+	tb     testing.TB
+	prefix string
+	done   bool
+}
+
+func (w *tbWriter) Write(p []byte) (int, error) {
+	w.tb.Log(w.prefix + strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// NewTBHandler creates a TBHandler that writes to tb, using the given options.
+// If opts is nil, the default options are used.
+func NewTBHandler(tb testing.TB, opts *TBHandlerOptions) *TBHandler {
+	if opts == nil {
+		opts = &TBHandlerOptions{}
+	}
+	w := &tbWriter{tb: tb}
+	tb.Cleanup(func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.done = true
+	})
+	return &TBHandler{
+		text:      slog.NewTextHandler(w, &opts.HandlerOptions),
+		w:         w,
+		failLevel: opts.FailLevel,
+	}
+}
+
+// Enabled implements slog.Handler interface.
+func (h *TBHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.text.Enabled(ctx, l)
+}
+
+// Handle implements slog.Handler interface.
+func (h *TBHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.w.mu.Lock()
+	defer h.w.mu.Unlock()
+	if h.w.done {
+		return nil
+	}
+
+	h.w.prefix = callerPrefix(r.PC)
+	err := h.text.Handle(ctx, r)
+	if h.failLevel != nil && r.Level >= h.failLevel.Level() {
+		h.w.tb.Errorf("%slog record with level %s: %s", h.w.prefix, r.Level, r.Message)
+	}
+	return err
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *TBHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.withText(h.text.WithAttrs(attrs))
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *TBHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withText(h.text.WithGroup(name))
+}
+
+func (h TBHandler) withText(text slog.Handler) *TBHandler {
+	h.text = text
+	return &h
+}
+
+// callerPrefix returns "file.go:42: " for pc or empty string if pc is 0.
+func callerPrefix(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line) + ": "
+}
@@ -0,0 +1,86 @@
+package slogxtest_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx/slogxtest"
+)
+
+type fakeTB struct {
+	testing.TB
+	name     string
+	failed   bool
+	logs     []string
+	errors   []string
+	cleanups []func()
+}
+
+func (*fakeTB) Helper() {}
+
+func (tb *fakeTB) Name() string { return tb.name }
+
+func (tb *fakeTB) Failed() bool { return tb.failed }
+
+func (tb *fakeTB) Cleanup(f func()) { tb.cleanups = append(tb.cleanups, f) }
+
+func (tb *fakeTB) Logf(format string, args ...any) {
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) done() {
+	for _, f := range tb.cleanups {
+		f()
+	}
+}
+
+func (tb *fakeTB) Log(args ...any) { tb.logs = append(tb.logs, fmt.Sprint(args...)) }
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTBHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	tb := &fakeTB{}
+	log := slog.New(slogxtest.NewTBHandler(tb, nil))
+	log.Debug("skipped")
+	log.With("a", 1).WithGroup("g").Error("failed", "b", 2)
+	t.Len(tb.logs, 1)
+	t.Match(tb.logs[0], `^tb_test.go:\d+: time=\S+ level=ERROR msg=failed a=1 g.b=2$`)
+	t.Len(tb.errors, 0)
+
+	tb = &fakeTB{}
+	h := slogxtest.NewTBHandler(tb, &slogxtest.TBHandlerOptions{
+		HandlerOptions: slog.HandlerOptions{
+			Level:       slog.LevelDebug,
+			ReplaceAttr: dropTime,
+		},
+		FailLevel: slog.LevelWarn,
+	})
+	checkWithNoop(t, h)
+	log = slog.New(h)
+	log.Debug("debug")
+	log.Warn("warn")
+	log.Error("error")
+	_ = h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "no pc", 0))
+	t.Match(strings.Join(tb.logs, "\n"), `^tb_test.go:\d+: level=DEBUG msg=debug
+tb_test.go:\d+: level=WARN msg=warn
+tb_test.go:\d+: level=ERROR msg=error
+level=INFO msg="no pc"$`)
+	t.Match(strings.Join(tb.errors, "\n"), `^tb_test.go:\d+: log record with level WARN: warn
+tb_test.go:\d+: log record with level ERROR: error$`)
+
+	tb.done()
+	log.Error("after test")
+	t.Len(tb.logs, 4)
+	t.Len(tb.errors, 2)
+}
@@ -0,0 +1,85 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+const msgSlowHandler = "slow log handler"
+
+// SlowHandler measures duration of next handler's Handle and reports
+// it using WARN record sent to a separate report handler when it exceeds
+// threshold. This makes visible the problem of slow log sink
+// slowing down the application.
+//
+// Report record has message "slow log handler" and attrs:
+//   - "duration": duration of slow Handle call,
+//   - "threshold": configured threshold,
+//   - "count": total amount of slow Handle calls,
+//   - "max": max duration of Handle call.
+type SlowHandler struct {
+	wrapHandler
+	report    slog.Handler
+	threshold time.Duration
+	now       func() time.Time
+	stats     *slowStats
+}
+
+type slowStats struct {
+	count atomic.Int64
+	max   atomic.Int64
+}
+
+// NewSlowHandler creates a SlowHandler which wraps next handler
+// and reports Handle calls slower than threshold to report handler.
+func NewSlowHandler(next, report slog.Handler, threshold time.Duration, opts ...handlerOption) *SlowHandler {
+	return &SlowHandler{
+		wrapHandler: wrapHandler{next: next},
+		report:      report,
+		threshold:   threshold,
+		now:         newHandlerOptions(opts).now,
+		stats:       &slowStats{},
+	}
+}
+
+// Handle implements slog.Handler interface.
+func (h *SlowHandler) Handle(ctx context.Context, r slog.Record) error {
+	start := h.now()
+	err := h.next.Handle(ctx, r)
+	end := h.now()
+	d := end.Sub(start)
+	if d <= h.threshold {
+		return err
+	}
+
+	count := h.stats.count.Add(1)
+	maxDur := h.stats.max.Load()
+	for int64(d) > maxDur && !h.stats.max.CompareAndSwap(maxDur, int64(d)) {
+		maxDur = h.stats.max.Load()
+	}
+	maxDur = max(maxDur, int64(d))
+
+	if h.report.Enabled(ctx, slog.LevelWarn) {
+		rec := slog.NewRecord(end, slog.LevelWarn, msgSlowHandler, 0)
+		rec.AddAttrs(
+			slog.Duration("duration", d),
+			slog.Duration("threshold", h.threshold),
+			slog.Int64("count", count),
+			slog.Duration("max", time.Duration(maxDur)),
+		)
+		_ = h.report.Handle(ctx, rec)
+	}
+	return err
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *SlowHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return withAttrs(h, attrs)
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *SlowHandler) WithGroup(name string) slog.Handler {
+	return withGroup(h, name)
+}
@@ -0,0 +1,56 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+	"go.uber.org/mock/gomock"
+
+	"github.com/powerman/slogx"
+)
+
+func TestSlowHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	var buf bytes.Buffer
+	ctx := context.Background()
+	next := NewMockHandler(ctrl)
+	report := slog.NewTextHandler(&buf, nil)
+	clock := newFakeClock()
+	h := slogx.NewSlowHandler(next, report, 5*time.Millisecond, slogx.ClockOption(clock.Now))
+
+	next.EXPECT().Enabled(ctx, slog.LevelDebug).Return(false)
+	t.False(h.Enabled(ctx, slog.LevelDebug))
+
+	next.EXPECT().Handle(ctx, gomock.Any()).Return(nil)
+	t.Nil(h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "fast", 0)))
+	t.Len(buf.String(), 0)
+
+	next.EXPECT().Handle(ctx, gomock.Any()).DoAndReturn(func(context.Context, slog.Record) error {
+		clock.Add(10 * time.Millisecond)
+		return io.EOF
+	})
+	t.Err(h.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "slow", 0)), io.EOF)
+	t.Equal(buf.String(), "time=2026-01-02T03:04:05.010Z level=WARN msg=\"slow log handler\" duration=10ms threshold=5ms count=1 max=10ms\n")
+
+	buf.Reset()
+	next2 := NewMockHandler(ctrl)
+	next.EXPECT().WithAttrs([]slog.Attr{slog.Int("a", 1)}).Return(next2)
+	next2.EXPECT().WithGroup("g").Return(next2)
+	next2.EXPECT().Handle(ctx, gomock.Any()).DoAndReturn(func(context.Context, slog.Record) error {
+		clock.Add(7 * time.Millisecond)
+		return nil
+	})
+	h2 := h.WithAttrs([]slog.Attr{slog.Int("a", 1)}).WithGroup("g")
+	t.Nil(h2.Handle(ctx, slog.NewRecord(time.Now(), slog.LevelInfo, "slow", 0)))
+	t.Equal(buf.String(), "time=2026-01-02T03:04:05.017Z level=WARN msg=\"slow log handler\" duration=7ms threshold=5ms count=2 max=10ms\n")
+
+	checkWithNoop(t, h)
+}
@@ -0,0 +1,160 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatsMaxDistinct is a maximum amount of distinct values tracked per key
+// by StatsHandler.
+const StatsMaxDistinct = 1000
+
+// KeyStats contains statistics collected by StatsHandler for attr key.
+type KeyStats struct {
+	// Key is qualified by groups separated by dot, e.g. "req.id".
+	Key string
+	// Count is amount of records which contain the key.
+	Count int64
+	// Distinct is amount of distinct values of the key,
+	// up to StatsMaxDistinct.
+	Distinct int
+}
+
+// StatsHandler collects statistics about how often each attr key appears
+// in records and how many distinct values it has over a time window
+// before passing records to next handler. It helps to find accidental
+// high-cardinality or useless attrs.
+type StatsHandler struct {
+	wrapHandler
+	attrs  []slog.Attr
+	prefix string
+	stats  *stats
+}
+
+type stats struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	window time.Duration
+	start  time.Time
+	keys   map[string]*keyStats
+	last   []KeyStats
+}
+
+type keyStats struct {
+	count  int64
+	values map[string]struct{}
+}
+
+// NewStatsHandler creates a StatsHandler which wraps next handler
+// and collects statistics over given window.
+func NewStatsHandler(next slog.Handler, window time.Duration, opts ...handlerOption) *StatsHandler {
+	now := newHandlerOptions(opts).now
+	return &StatsHandler{
+		wrapHandler: wrapHandler{next: next},
+		stats: &stats{
+			now:    now,
+			window: window,
+			start:  now(),
+			keys:   make(map[string]*keyStats),
+		},
+	}
+}
+
+// Handle implements slog.Handler interface.
+func (h *StatsHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := getAttrs(len(h.attrs) + r.NumAttrs())
+	defer putAttrs(attrs)
+	*attrs = append(*attrs, h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		*attrs = appendFlatAttr(*attrs, h.prefix, a)
+		return true
+	})
+	h.stats.add(*attrs)
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *StatsHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithAttrs(attrs)
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = appendFlatAttr(h2.attrs, h.prefix, a)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *StatsHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.next = h.next.WithGroup(name)
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// Stats returns statistics for the last completed window, or for the
+// current window if no window has completed yet. Stats are sorted by key.
+func (h *StatsHandler) Stats() []KeyStats {
+	h.stats.mu.Lock()
+	defer h.stats.mu.Unlock()
+
+	h.stats.rotate(h.stats.now())
+	if h.stats.last != nil {
+		return slices.Clone(h.stats.last)
+	}
+	return h.stats.snapshot()
+}
+
+func (s *stats) add(attrs []slog.Attr) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rotate(s.now())
+	for _, a := range attrs {
+		ks := s.keys[a.Key]
+		if ks == nil {
+			ks = &keyStats{values: make(map[string]struct{})}
+			s.keys[a.Key] = ks
+		}
+		ks.count++
+		if len(ks.values) < StatsMaxDistinct {
+			ks.values[a.Value.String()] = struct{}{}
+		}
+	}
+}
+
+// rotate starts a new window if current one has ended. Windows are
+// aligned to start time of the first window. If there was no records
+// during whole last completed window then it has empty stats.
+func (s *stats) rotate(now time.Time) {
+	elapsed := now.Sub(s.start)
+	if elapsed < s.window {
+		return
+	}
+	if elapsed < 2*s.window {
+		s.last = s.snapshot()
+	} else {
+		s.last = []KeyStats{}
+	}
+	s.start = s.start.Add(elapsed.Truncate(s.window))
+	s.keys = make(map[string]*keyStats)
+}
+
+func (s *stats) snapshot() []KeyStats {
+	res := make([]KeyStats, 0, len(s.keys))
+	for key, ks := range s.keys {
+		res = append(res, KeyStats{Key: key, Count: ks.count, Distinct: len(ks.values)})
+	}
+	slices.SortFunc(res, func(a, b KeyStats) int { return strings.Compare(a.Key, b.Key) })
+	return res
+}
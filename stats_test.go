@@ -0,0 +1,55 @@
+package slogx_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestStatsHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	next := slog.NewTextHandler(io.Discard, nil)
+	clock := newFakeClock()
+	h := slogx.NewStatsHandler(next, time.Minute, slogx.ClockOption(clock.Now))
+	t.Equal(h.Unwrap(), next)
+	checkWithNoop(t, h)
+	t.False(h.Enabled(context.Background(), slog.LevelDebug))
+	t.DeepEqual(h.Stats(), []slogx.KeyStats{})
+
+	log := slog.New(h).With("app", "test").WithGroup("req")
+	for i := range slogx.StatsMaxDistinct + 10 {
+		log.Info("msg", "id", i, "method", []string{"GET", "POST"}[i%2])
+	}
+	log.Info("msg", slog.Group("resp", "code", 200))
+	want := []slogx.KeyStats{
+		{Key: "app", Count: slogx.StatsMaxDistinct + 11, Distinct: 1},
+		{Key: "req.id", Count: slogx.StatsMaxDistinct + 10, Distinct: slogx.StatsMaxDistinct},
+		{Key: "req.method", Count: slogx.StatsMaxDistinct + 10, Distinct: 2},
+		{Key: "req.resp.code", Count: 1, Distinct: 1},
+	}
+	t.DeepEqual(h.Stats(), want)
+
+	clock.Add(time.Minute - 1)
+	t.DeepEqual(h.Stats(), want)
+	clock.Add(1)
+	log.Info("msg")
+	t.DeepEqual(h.Stats(), want)
+
+	clock.Add(time.Minute)
+	t.DeepEqual(h.Stats(), []slogx.KeyStats{{Key: "app", Count: 1, Distinct: 1}})
+
+	clock.Add(2*time.Minute + 30*time.Second)
+	t.DeepEqual(h.Stats(), []slogx.KeyStats{})
+	log.Info("msg")
+	t.DeepEqual(h.Stats(), []slogx.KeyStats{})
+	clock.Add(30 * time.Second)
+	t.DeepEqual(h.Stats(), []slogx.KeyStats{{Key: "app", Count: 1, Distinct: 1}})
+}
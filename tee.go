@@ -0,0 +1,118 @@
+package slogx
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// teeQueueSize is the maximum amount of records queued for a tee writer.
+const teeQueueSize = 1024
+
+type teeOutputer interface {
+	teeOutput(w io.Writer) (stop func())
+}
+
+// TeeOutput temporarily duplicates output of handler to extra writer w,
+// e.g. to a debug session attached over a unix socket, without
+// reconstructing the handler chain. Call stop to detach w.
+//
+// Records are written to w by a separate goroutine after handler's own
+// output, so slow or stalled w never blocks logging. Writer w is detached
+// on first write error or when it falls behind by 1024 records, and its
+// errors never affect the handler. Stop waits until records already
+// queued for w are written, so it blocks while w is stalled.
+//
+// It walks handler and handlers wrapped by it (like LevelerOf) to find
+// a handler which writes to io.Writer: RemoteHandler (w gets a separate
+// stream readable by ServeRemoteHandler) or TemplateHandler.
+// It returns false and no-op stop if handler does not provide it.
+func TeeOutput(handler slog.Handler, w io.Writer) (stop func(), ok bool) {
+	for handler != nil {
+		if h, ok := handler.(teeOutputer); ok {
+			return h.teeOutput(w), true
+		}
+		h, ok := handler.(interface{ Unwrap() slog.Handler })
+		if !ok {
+			break
+		}
+		handler = h.Unwrap()
+	}
+	return func() {}, false
+}
+
+// teeWriters is a set of tee writers with per-writer state T,
+// guarded by the mutex of a handler which owns it.
+type teeWriters[T any] map[*teeWriter]T
+
+// add adds tw to tees and returns stop func which detaches it.
+func (tees teeWriters[T]) add(mu *sync.Mutex, tw *teeWriter, v T) (stop func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	tees[tw] = v
+	return func() {
+		mu.Lock()
+		tees.detach(tw)
+		mu.Unlock()
+		tw.wait()
+	}
+}
+
+// detach removes tw from tees. It is a no-op if tw was already detached.
+func (tees teeWriters[T]) detach(tw *teeWriter) {
+	if _, ok := tees[tw]; ok {
+		delete(tees, tw)
+		close(tw.queue)
+	}
+}
+
+// teeWriter writes queued buffers to w in a separate goroutine.
+type teeWriter struct {
+	w      io.Writer
+	queue  chan []byte
+	done   chan struct{}
+	failed atomic.Bool
+}
+
+func newTeeWriter(w io.Writer) *teeWriter {
+	tw := &teeWriter{
+		w:     w,
+		queue: make(chan []byte, teeQueueSize),
+		done:  make(chan struct{}),
+	}
+	go tw.run()
+	return tw
+}
+
+func (tw *teeWriter) run() {
+	defer close(tw.done)
+	for buf := range tw.queue {
+		if tw.failed.Load() {
+			continue
+		}
+		if _, err := tw.w.Write(buf); err != nil {
+			tw.failed.Store(true)
+		}
+	}
+}
+
+// send queues buf without blocking. It returns false if w has failed
+// or queue is full, so tw should be detached.
+// Caller must not modify buf after send.
+func (tw *teeWriter) send(buf []byte) bool {
+	if tw.failed.Load() {
+		return false
+	}
+	select {
+	case tw.queue <- buf:
+		return true
+	default:
+		return false
+	}
+}
+
+// wait waits until buffers queued before detach are written.
+func (tw *teeWriter) wait() {
+	<-tw.done
+}
@@ -0,0 +1,88 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+type blockWriter struct {
+	unblock chan struct{}
+}
+
+func (w blockWriter) Write(buf []byte) (int, error) {
+	<-w.unblock
+	return len(buf), nil
+}
+
+func TestTeeOutput(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var stream, teeStream bytes.Buffer
+	log := slog.New(slogx.NewSlowHandler(slogx.NewRemoteHandler(&stream, nil), nopHandler{}, 0))
+	log.Info("first")
+	stop, ok := slogx.TeeOutput(log.Handler(), &teeStream)
+	t.True(ok)
+	log.WithGroup("g").Info("second", "a", 1)
+	stop()
+	stop()
+	log.Info("third")
+
+	stop, ok = slogx.TeeOutput(log.Handler(), errWriter{})
+	t.True(ok)
+	log.Info("fourth")
+	log.Info("fifth")
+	stop()
+
+	var out, teeOut bytes.Buffer
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &stream, slog.NewTextHandler(&out, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &teeStream, slog.NewTextHandler(&teeOut, &slog.HandlerOptions{ReplaceAttr: dropTime})))
+	t.Equal(out.String(), "level=INFO msg=first\nlevel=INFO msg=second g.a=1\nlevel=INFO msg=third\nlevel=INFO msg=fourth\nlevel=INFO msg=fifth\n")
+	t.Equal(teeOut.String(), "level=INFO msg=second g.a=1\n")
+
+	var buf, tee bytes.Buffer
+	tmpl := template.Must(template.New("").Parse(`{{.Message}}`))
+	log = slog.New(slogx.NewSlowHandler(slogx.NewTemplateHandler(&buf, tmpl, nil), nopHandler{}, 0))
+	log.Info("first")
+	stop, ok = slogx.TeeOutput(log.Handler(), &tee)
+	t.True(ok)
+	log.With("a", 1).Info("second")
+	stop()
+	log.Info("third")
+	t.Equal(buf.String(), "first\nsecond\nthird\n")
+	t.Equal(tee.String(), "second\n")
+
+	stop, ok = slogx.TeeOutput(slog.NewTextHandler(io.Discard, nil), &tee)
+	t.False(ok)
+	stop()
+}
+
+func TestTeeOutputStalled(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	const records = 2000
+	var stream bytes.Buffer
+	log := slog.New(slogx.NewRemoteHandler(&stream, nil))
+	w := blockWriter{unblock: make(chan struct{})}
+	stop, ok := slogx.TeeOutput(log.Handler(), w)
+	t.True(ok)
+	for range records {
+		log.Info("msg")
+	}
+	close(w.unblock)
+	stop()
+
+	var out bytes.Buffer
+	t.Nil(slogx.ServeRemoteHandler(context.Background(), &stream, slog.NewTextHandler(&out, nil)))
+	t.Equal(strings.Count(out.String(), "\n"), records)
+}
@@ -0,0 +1,204 @@
+package slogx
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"runtime"
+	"slices"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// TemplateRecord is a data passed to a template of TemplateHandler.
+type TemplateRecord struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	// Source is nil if record has no source location.
+	Source *slog.Source
+	// Attrs contains values of all attrs (including added using WithAttrs)
+	// with keys qualified by groups separated by dot, e.g. "req.id".
+	// Missing keys result in nil value which is false in template conditions.
+	Attrs map[string]any
+	// AttrList contains same attrs in order they were added.
+	AttrList []slog.Attr
+}
+
+// TemplateHandlerOptions are options for a TemplateHandler.
+type TemplateHandlerOptions struct {
+	// Level reports the minimum record level that will be logged.
+	// If nil, the handler assumes slog.LevelInfo.
+	Level slog.Leveler
+	// FlushLevel makes handler flush writer (see Flush) after writing
+	// records at or above this level. If nil, writer is not flushed.
+	FlushLevel slog.Leveler
+	// OnWriteError is called with a record and an error returned by
+	// writer (or its flush), because slog.Logger ignores errors returned
+	// by Handle. It may count failures, log record using another handler
+	// (e.g. to stderr), or panic. Handle still returns the error after
+	// OnWriteError returns.
+	OnWriteError func(slog.Record, error)
+}
+
+// TemplateHandler is an alternative to layout configured by per-key formats:
+// it formats whole record using text/template executed with TemplateRecord.
+// This makes possible layouts with conditional sections, e.g.:
+//
+//	{{.Time.Format "15:04:05"}} {{.Level}} {{.Message}}{{with .Attrs.user}} by {{.}}{{end}}
+//
+// Newline is added to template output if it does not end with newline.
+//
+// TemplateHandler implements Flusher.
+// Output may be duplicated to another writer using TeeOutput.
+type TemplateHandler struct {
+	mu         *sync.Mutex
+	w          io.Writer
+	tmpl       *template.Template
+	level      slog.Leveler
+	flushLevel slog.Leveler
+	onError    func(slog.Record, error)
+	tees       teeWriters[struct{}]
+	attrs      []slog.Attr
+	prefix     string
+}
+
+// NewTemplateHandler creates a TemplateHandler that writes to w,
+// using the given template and options.
+// If opts is nil, the default options are used.
+func NewTemplateHandler(w io.Writer, tmpl *template.Template, opts *TemplateHandlerOptions) *TemplateHandler {
+	if opts == nil {
+		opts = &TemplateHandlerOptions{}
+	}
+	level := opts.Level
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &TemplateHandler{
+		mu:         &sync.Mutex{},
+		w:          w,
+		tmpl:       tmpl,
+		level:      level,
+		flushLevel: opts.FlushLevel,
+		onError:    opts.OnWriteError,
+		tees:       make(teeWriters[struct{}]),
+	}
+}
+
+// Enabled implements slog.Handler interface.
+func (h *TemplateHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= h.level.Level()
+}
+
+// Handle implements slog.Handler interface.
+func (h *TemplateHandler) Handle(_ context.Context, r slog.Record) error {
+	data := TemplateRecord{
+		Time:     r.Time,
+		Level:    r.Level,
+		Message:  r.Message,
+		AttrList: slices.Clip(h.attrs),
+	}
+	if r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		data.Source = &slog.Source{Function: frame.Function, File: frame.File, Line: frame.Line}
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		data.AttrList = appendFlatAttr(data.AttrList, h.prefix, a)
+		return true
+	})
+	data.Attrs = make(map[string]any, len(data.AttrList))
+	for _, a := range data.AttrList {
+		data.Attrs[a.Key] = a.Value.Any()
+	}
+
+	var buf bytes.Buffer
+	err := h.tmpl.Execute(&buf, data)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+
+	err = h.write(buf.Bytes(), r.Level)
+	if err != nil && h.onError != nil {
+		h.onError(r, err)
+	}
+	return err
+}
+
+func (h *TemplateHandler) write(buf []byte, level slog.Level) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf)
+	if err == nil && h.flushLevel != nil && level >= h.flushLevel.Level() {
+		err = flushWriter(h.w)
+	}
+	for tw := range h.tees {
+		if !tw.send(buf) {
+			h.tees.detach(tw)
+		}
+	}
+	return err
+}
+
+func (h *TemplateHandler) teeOutput(w io.Writer) func() {
+	return h.tees.add(h.mu, newTeeWriter(w), struct{}{})
+}
+
+// Flush implements Flusher interface.
+// It flushes w given to NewTemplateHandler if it implements Flush() error
+// (e.g. *bufio.Writer) or Sync() error (e.g. *os.File).
+func (h *TemplateHandler) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return flushWriter(h.w)
+}
+
+// WithAttrs implements slog.Handler interface.
+func (h *TemplateHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = appendFlatAttr(h2.attrs, h.prefix, a)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler interface.
+func (h *TemplateHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.prefix = h.prefix + name + "."
+	return &h2
+}
+
+// Leveler returns level configured in TemplateHandlerOptions.
+func (h *TemplateHandler) Leveler() slog.Leveler {
+	return h.level
+}
+
+func appendFlatAttr(attrs []slog.Attr, prefix string, a slog.Attr) []slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch {
+	case a.Equal(slog.Attr{}):
+	case a.Value.Kind() == slog.KindGroup:
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			attrs = appendFlatAttr(attrs, prefix, ga)
+		}
+	default:
+		a.Key = prefix + a.Key
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
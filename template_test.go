@@ -0,0 +1,56 @@
+package slogx_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestTemplateHandler(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("").Parse(
+		`{{.Time.Format "15:04:05"}} {{.Level}} {{.Message}}` +
+			`{{with .Attrs.user}} by {{.}}{{end}}` +
+			`{{with .Source}} at {{.Line}}{{end}}` +
+			`{{range .AttrList}} {{.Key}}={{.Value}}{{end}}`))
+	h := slogx.NewTemplateHandler(&buf, tmpl, nil)
+	t.True(h.Enabled(context.Background(), slog.LevelInfo))
+	t.False(h.Enabled(context.Background(), slog.LevelDebug))
+	checkWithNoop(t, h)
+	l, ok := slogx.LevelerOf(h)
+	t.True(ok)
+	t.Equal(l, slog.LevelInfo)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	r := slog.NewRecord(now, slog.LevelInfo, "first", 0)
+	r.AddAttrs(slog.Int("a", 1), slog.Group("g", slog.Int("b", 2), slog.Group("", slog.Int("c", 3))), slog.Group("empty"))
+	t.Nil(h.Handle(context.Background(), r))
+	t.Equal(buf.String(), "03:04:05 INFO first a=1 g.b=2 g.c=3\n")
+
+	buf.Reset()
+	h2 := h.WithAttrs([]slog.Attr{slog.String("user", "bob")}).WithGroup("req").WithAttrs([]slog.Attr{slog.Int("id", 42)})
+	r = slog.NewRecord(now, slog.LevelWarn, "second", 0)
+	r.AddAttrs(slog.Int("status", 200))
+	t.Nil(h2.Handle(context.Background(), r))
+	t.Equal(buf.String(), "03:04:05 WARN second by bob user=bob req.id=42 req.status=200\n")
+
+	buf.Reset()
+	tmpl = template.Must(template.New("").Parse("{{.Message}} at {{.Source.Line}}\n"))
+	log := slog.New(slogx.NewTemplateHandler(&buf, tmpl, &slogx.TemplateHandlerOptions{Level: slog.LevelDebug}))
+	log.Debug("third")
+	t.Match(buf.String(), `^third at \d+\n$`)
+
+	tmpl = template.Must(template.New("").Parse("{{.Bad}}"))
+	t.Match(slogx.NewTemplateHandler(io.Discard, tmpl, nil).Handle(context.Background(), r), `can't evaluate field Bad`)
+}
@@ -0,0 +1,30 @@
+package slogx
+
+import "runtime/debug"
+
+const modulePath = "github.com/powerman/slogx"
+
+// Version returns version of this module used by the binary
+// (e.g. "v1.2.3" or "(devel)") or empty string if it is unknown.
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	return moduleVersion(info)
+}
+
+func moduleVersion(info *debug.BuildInfo) string {
+	if info.Main.Path == modulePath {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package slogx_test
+
+import (
+	"testing"
+
+	"github.com/powerman/check"
+
+	"github.com/powerman/slogx"
+)
+
+func TestVersion(tt *testing.T) {
+	t := check.T(tt)
+	t.Parallel()
+
+	// Test binary is built from this module, which is the main module.
+	t.Equal(slogx.Version(), "(devel)")
+}
@@ -0,0 +1,97 @@
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// wrapHandler is embedded by handlers which wrap next handler.
+// It implements Enabled and Unwrap, and withAttrs and withGroup helpers implement
+// WithAttrs and WithGroup for handlers which have no own per-attr or
+// per-group state.
+type wrapHandler struct {
+	next slog.Handler
+}
+
+type wrapper[T any] interface {
+	*T
+	slog.Handler
+	wrapped() *wrapHandler
+}
+
+// Enabled implements slog.Handler interface.
+func (h *wrapHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return h.next.Enabled(ctx, l)
+}
+
+// Unwrap returns next handler.
+func (h *wrapHandler) Unwrap() slog.Handler {
+	return h.next
+}
+
+func (h *wrapHandler) wrapped() *wrapHandler {
+	return h
+}
+
+// withAttrs returns a copy of h which wraps next.WithAttrs(attrs).
+func withAttrs[T any, P wrapper[T]](h P, attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	h2 := *h
+	P(&h2).wrapped().next = h.wrapped().next.WithAttrs(attrs)
+	return P(&h2)
+}
+
+// withGroup returns a copy of h which wraps next.WithGroup(name).
+func withGroup[T any, P wrapper[T]](h P, name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	P(&h2).wrapped().next = h.wrapped().next.WithGroup(name)
+	return P(&h2)
+}
+
+// outsideGroups makes possible to add attrs outside of groups added by
+// WithGroup: it keeps handler before first WithGroup and replays
+// WithAttrs and WithGroup calls made since then. It is slow, so it should
+// be used only for rarely added or debugging attrs.
+type outsideGroups struct {
+	root slog.Handler // Before first WithGroup.
+	ops  []handlerOp  // Since first WithGroup.
+}
+
+// withAttrs should be called with next handler after WithAttrs(attrs).
+func (g outsideGroups) withAttrs(next slog.Handler, attrs []slog.Attr) outsideGroups {
+	if len(g.ops) == 0 {
+		g.root = next
+	} else {
+		g.ops = append(slices.Clip(g.ops), handlerOp{attrs: attrs})
+	}
+	return g
+}
+
+func (g outsideGroups) withGroup(name string) outsideGroups {
+	g.ops = append(slices.Clip(g.ops), handlerOp{group: name})
+	return g
+}
+
+// grouped reports is there any groups to be outside of.
+func (g outsideGroups) grouped() bool {
+	return len(g.ops) > 0
+}
+
+// handler returns handler with attrs added outside of groups.
+func (g outsideGroups) handler(attrs ...slog.Attr) slog.Handler {
+	handler := g.root.WithAttrs(attrs)
+	for _, op := range g.ops {
+		if op.group != "" {
+			handler = handler.WithGroup(op.group)
+		} else {
+			handler = handler.WithAttrs(op.attrs)
+		}
+	}
+	return handler
+}